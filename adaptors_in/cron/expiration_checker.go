@@ -25,3 +25,36 @@ func (h *CampaignsHandler) CampaignExpirationChecker(log zerolog.Logger) {
 
 	cronjob.Start()
 }
+
+// ExposureExpirationChecker drops every hour the user deliveries older than the exposure ttl.
+func (h *CampaignsHandler) ExposureExpirationChecker(log zerolog.Logger) {
+	cronjob := cron.New()
+
+	// runs every hour at minute 0
+	_, err := cronjob.AddFunc("0 * * * *", h.UseCase.DeleteExpiredExposures)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to delete expired exposures")
+	}
+
+	cronjob.Start()
+}
+
+// ReservationExpirationChecker releases every minute the cost of the deliveries
+// whose impression did not come in time back to the campaign budgets.
+func (h *CampaignsHandler) ReservationExpirationChecker(log zerolog.Logger) {
+	cronjob := cron.New()
+
+	// runs every minute
+	_, err := cronjob.AddFunc("* * * * *", h.UseCase.ReleaseExpiredDeliveries)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to release expired reservations")
+	}
+
+	cronjob.Start()
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
+	"time"
 
 	"ad-campaign-delivery/model"
 	"ad-campaign-delivery/pkg"
@@ -13,20 +15,78 @@ import (
 
 type CampaignsHandler struct {
 	UseCase ports_in.CampaignService
+	Signer  URLSigner
+	// ExposureTTL is how long the deliveries to a user are kept, bounding the frequency cap periods,
+	// recency caps and sequence delays of the campaigns. Zero leaves them unbounded.
+	ExposureTTL time.Duration
 }
 
 type CampaignCreateRequest struct {
-	ID         string          `json:"id"`
-	Country    string          `json:"country"`
-	Device     string          `json:"device"`
-	OS         string          `json:"os"`
-	Bid        decimal.Decimal `json:"bid"`
-	Budget     decimal.Decimal `json:"budget"`
-	ActiveDays int             `json:"active_days"`
+	ID         string `json:"id"`
+	Advertiser string `json:"advertiser"`
+	// Category is the industry of the campaign, e.g. automotive.
+	Category string `json:"category"`
+	// Country, Device and OS match any value when empty.
+	Country      string              `json:"country"`
+	Device       string              `json:"device"`
+	OS           string              `json:"os"`
+	Bid          decimal.Decimal     `json:"bid"`
+	BidModifiers BidModifiersRequest `json:"bid_modifiers"`
+	PricingModel string              `json:"pricing_model"`
+	Priority     string              `json:"priority"`
+	Budget       decimal.Decimal     `json:"budget"`
+	// ImpressionGoal is the number of deliveries sold until the campaign expires, instead of a budget.
+	ImpressionGoal int64               `json:"impression_goal"`
+	FrequencyCap   FrequencyCapRequest `json:"frequency_cap"`
+	// RecencyCap is the minimum duration between two deliveries to the same user, e.g. 10m.
+	RecencyCap string          `json:"recency_cap"`
+	Sequence   SequenceRequest `json:"sequence"`
+	// ClickURL is the landing page the clicks are redirected to.
+	ClickURL string `json:"click_url"`
+	// Formats lists the formats of the slots the campaign is delivered in, banner, native or video,
+	// and Sizes the sizes of its banner slots, e.g. 728x90. Both match any slot when empty.
+	Formats    []string `json:"formats"`
+	Sizes      []string `json:"sizes"`
+	ActiveDays int      `json:"active_days"`
+}
+
+// SequenceRequest delivers the campaign only to users who were delivered
+// the campaign with ID after, at least min_delay earlier, e.g. 1h.
+type SequenceRequest struct {
+	After    string `json:"after"`
+	MinDelay string `json:"min_delay"`
+}
+
+// FrequencyCapRequest limits the deliveries to the same user, e.g. 3 impressions per 24h.
+type FrequencyCapRequest struct {
+	Impressions int    `json:"impressions"`
+	Period      string `json:"period"`
+}
+
+// BidModifiersRequest holds the multipliers of the bid per targeting value and hour of the day.
+type BidModifiersRequest struct {
+	Country map[string]decimal.Decimal `json:"country"`
+	Device  map[string]decimal.Decimal `json:"device"`
+	OS      map[string]decimal.Decimal `json:"os"`
+	Hour    map[int]decimal.Decimal    `json:"hour"`
 }
 
 // @Summary      Create a new campaign
 // @Description  A campaign and a bid lookup will be created with the provided fields.
+// @Description  The pricing model is one of cpd (bid per delivery, default), cpm, cpc or cpa.
+// @Description  Empty country, device or os match any value, and bid modifiers multiply the bid
+// @Description  per country, device, os and hour of the day (0 to 23) of the delivery.
+// @Description  The frequency cap limits the deliveries to the same user within a period, e.g. 3 per 24h,
+// @Description  and the recency cap sets the minimum time between two deliveries to the same user.
+// @Description  A sequence delivers the campaign only to users who were delivered the campaign it follows.
+// @Description  The frequency cap period, recency cap and sequence min_delay cannot be longer than the exposure ttl.
+// @Description  Campaigns of the same category from different advertisers are never delivered together.
+// @Description  The priority is one of guaranteed (wins before the auction at its bid), standard (default)
+// @Description  or house (free, without budget, only filling the slots no other campaign won).
+// @Description  An impression goal sells a number of deliveries, spread evenly until the campaign expires,
+// @Description  instead of a budget: it requires active_days and no budget, and is best paired with guaranteed.
+// @Description  Formats and sizes restrict the delivery to the slots of one of the formats and, for banners,
+// @Description  of one of the sizes, only the creatives the campaign allows being delivered.
 // @Tags         campaigns
 // @Accept       json
 // @Param        request  body  CampaignCreateRequest  true  "Campaign create request"
@@ -50,19 +110,19 @@ func (h *CampaignsHandler) create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	country, ok := model.Countries[input.Country]
-	if !ok {
+	if !ok && input.Country != "" {
 		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid country: %v", input.Country))
 		return
 	}
 
 	device, ok := model.Devices[input.Device]
-	if !ok {
+	if !ok && input.Device != "" {
 		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid device: %v", input.Device))
 		return
 	}
 
 	os, ok := model.OperationalSystems[input.OS]
-	if !ok {
+	if !ok && input.OS != "" {
 		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid os: %v", input.OS))
 		return
 	}
@@ -72,18 +132,120 @@ func (h *CampaignsHandler) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	bidModifiers, err := parseBidModifiers(input.BidModifiers)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid bid_modifiers: %v", err))
+		return
+	}
+
+	pricingModel := model.CPD
+	if input.PricingModel != "" {
+		pricingModel, ok = model.PricingModels[input.PricingModel]
+		if !ok {
+			pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid pricing_model: %v", input.PricingModel))
+			return
+		}
+	}
+
+	priority := model.Standard
+	if input.Priority != "" {
+		priority, ok = model.Priorities[input.Priority]
+		if !ok {
+			pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid priority: %v", input.Priority))
+			return
+		}
+	}
+
 	if input.Budget.IsNegative() {
 		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid budget: %v", input.Budget))
 		return
 	}
+	if priority == model.House && !input.Budget.IsZero() {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid budget: %v, house campaigns have no budget", input.Budget))
+		return
+	}
+
+	if input.ImpressionGoal < 0 {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid impression_goal: %v", input.ImpressionGoal))
+		return
+	}
+	if input.ImpressionGoal > 0 {
+		if !input.Budget.IsZero() {
+			pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid budget: %v, impression goal campaigns have no budget", input.Budget))
+			return
+		}
+		if input.ActiveDays <= 0 {
+			pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid active_days: %v, impression goal campaigns must expire", input.ActiveDays))
+			return
+		}
+	}
+
+	frequencyCap, err := parseFrequencyCap(input.FrequencyCap, h.ExposureTTL)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid frequency_cap: %v", err))
+		return
+	}
+
+	var recencyCap time.Duration
+	if input.RecencyCap != "" {
+		recencyCap, err = time.ParseDuration(input.RecencyCap)
+		if err != nil || recencyCap <= 0 {
+			pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid recency_cap: %v", input.RecencyCap))
+			return
+		}
+		if exceedsTTL(recencyCap, h.ExposureTTL) {
+			pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid recency_cap: %v, longer than the exposure ttl %v",
+				input.RecencyCap, h.ExposureTTL))
+			return
+		}
+	}
+
+	sequence, err := parseSequence(input.Sequence, input.ID, h.ExposureTTL)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid sequence: %v", err))
+		return
+	}
+
+	if input.ClickURL != "" && !isWebURL(input.ClickURL) {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid click_url: %v", input.ClickURL))
+		return
+	}
+
+	formats, err := parseFormats(input.Formats)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, err.Error())
+		return
+	}
+
+	sizes, err := parseSizes(input.Sizes)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, err.Error())
+		return
+	}
+	if len(sizes) > 0 && len(formats) > 0 && !slices.Contains(formats, model.Banner) {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid sizes: %v, only banners have sizes", input.Sizes))
+		return
+	}
 
 	campaign := model.Campaign{
-		ID:      input.ID,
-		Country: country,
-		Device:  device,
-		OS:      os,
-		Bid:     input.Bid,
-		Budget:  input.Budget,
+		ID:             input.ID,
+		Advertiser:     input.Advertiser,
+		Category:       model.Category(input.Category),
+		Country:        country,
+		Device:         device,
+		OS:             os,
+		Bid:            input.Bid,
+		BidModifiers:   bidModifiers,
+		PricingModel:   pricingModel,
+		Priority:       priority,
+		Budget:         input.Budget,
+		ImpressionGoal: input.ImpressionGoal,
+		FrequencyCap:   frequencyCap,
+		RecencyCap:     recencyCap,
+		Sequence:       sequence,
+		ClickURL:       input.ClickURL,
+		Formats:        formats,
+		Sizes:          sizes,
 	}
 
 	err = h.UseCase.Create(ctx, campaign, input.ActiveDays)
@@ -95,82 +257,336 @@ func (h *CampaignsHandler) create(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// parseFrequencyCap validates the cap, which is unset when it has no impressions, and whose period
+// cannot be longer than the exposure ttl.
+func parseFrequencyCap(input FrequencyCapRequest, ttl time.Duration) (model.FrequencyCap, error) {
+	if input.Impressions == 0 && input.Period == "" {
+		return model.FrequencyCap{}, nil
+	}
+	if input.Impressions <= 0 {
+		return model.FrequencyCap{}, fmt.Errorf("impressions %v must be positive", input.Impressions)
+	}
+
+	period, err := time.ParseDuration(input.Period)
+	if err != nil || period <= 0 {
+		return model.FrequencyCap{}, fmt.Errorf("period %q must be a positive duration, e.g. 24h", input.Period)
+	}
+	if exceedsTTL(period, ttl) {
+		return model.FrequencyCap{}, fmt.Errorf("period %q is longer than the exposure ttl %v", input.Period, ttl)
+	}
+	return model.FrequencyCap{Impressions: input.Impressions, Period: period}, nil
+}
+
+// parseSequence validates the sequence of the campaign, which cannot follow itself nor wait
+// longer than the exposure ttl.
+func parseSequence(input SequenceRequest, campaignID string, ttl time.Duration) (model.Sequence, error) {
+	if input.After == "" {
+		if input.MinDelay != "" {
+			return model.Sequence{}, fmt.Errorf("min_delay %q without after", input.MinDelay)
+		}
+		return model.Sequence{}, nil
+	}
+	if input.After == campaignID {
+		return model.Sequence{}, fmt.Errorf("campaign %v cannot follow itself", campaignID)
+	}
+
+	var minDelay time.Duration
+	if input.MinDelay != "" {
+		var err error
+		minDelay, err = time.ParseDuration(input.MinDelay)
+		if err != nil || minDelay < 0 {
+			return model.Sequence{}, fmt.Errorf("min_delay %q must be a duration, e.g. 1h", input.MinDelay)
+		}
+		if exceedsTTL(minDelay, ttl) {
+			return model.Sequence{}, fmt.Errorf("min_delay %q is longer than the exposure ttl %v", input.MinDelay, ttl)
+		}
+	}
+	return model.Sequence{After: input.After, MinDelay: minDelay}, nil
+}
+
+// exceedsTTL tells whether the duration outlasts the exposure ttl, the deliveries it looks
+// back on being forgotten by then. A zero ttl is unbounded.
+func exceedsTTL(d, ttl time.Duration) bool {
+	return ttl > 0 && d > ttl
+}
+
+// parseBidModifiers validates the targeting values and hours of the modifiers,
+// which must all be positive.
+func parseBidModifiers(input BidModifiersRequest) (model.BidModifiers, error) {
+	var modifiers model.BidModifiers
+
+	for value, modifier := range input.Country {
+		country, ok := model.Countries[value]
+		if !ok || !modifier.IsPositive() {
+			return model.BidModifiers{}, fmt.Errorf("country %v: %v", value, modifier)
+		}
+		if modifiers.Country == nil {
+			modifiers.Country = map[model.Country]decimal.Decimal{}
+		}
+		modifiers.Country[country] = modifier
+	}
+	for value, modifier := range input.Device {
+		device, ok := model.Devices[value]
+		if !ok || !modifier.IsPositive() {
+			return model.BidModifiers{}, fmt.Errorf("device %v: %v", value, modifier)
+		}
+		if modifiers.Device == nil {
+			modifiers.Device = map[model.Device]decimal.Decimal{}
+		}
+		modifiers.Device[device] = modifier
+	}
+	for value, modifier := range input.OS {
+		os, ok := model.OperationalSystems[value]
+		if !ok || !modifier.IsPositive() {
+			return model.BidModifiers{}, fmt.Errorf("os %v: %v", value, modifier)
+		}
+		if modifiers.OS == nil {
+			modifiers.OS = map[model.OS]decimal.Decimal{}
+		}
+		modifiers.OS[os] = modifier
+	}
+	for hour, modifier := range input.Hour {
+		if hour < 0 || hour > 23 || !modifier.IsPositive() {
+			return model.BidModifiers{}, fmt.Errorf("hour %v: %v", hour, modifier)
+		}
+		if modifiers.Hour == nil {
+			modifiers.Hour = map[int]decimal.Decimal{}
+		}
+		modifiers.Hour[hour] = modifier
+	}
+	return modifiers, nil
+}
+
 type CampaignMatchRequest struct {
-	Country string `json:"country"`
-	Device  string `json:"device"`
-	OS      string `json:"os"`
+	Country  string          `json:"country"`
+	Device   string          `json:"device"`
+	OS       string          `json:"os"`
+	BidFloor decimal.Decimal `json:"bid_floor"`
+	// Slots requests up to this many distinct campaigns, answered with CampaignsMatchResponse.
+	Slots int `json:"slots"`
+	// Formats lists the creative formats the slots accept, banner, native or video, only the campaigns
+	// allowing one of them with a creative of one of them being delivered.
+	Formats []string `json:"formats"`
+	// Sizes lists the sizes the banner slots accept, e.g. 300x250, only the campaigns allowing one of
+	// them with a banner fitting one of them being delivered.
+	Sizes             []string `json:"sizes"`
+	UniqueAdvertisers bool     `json:"unique_advertisers"`
+	// UserID identifies the user for frequency and recency capping and sequencing.
+	UserID string `json:"user_id"`
+	// PageViewID identifies the page view of the slots, to exclude the competitors of the
+	// categories delivered by previous requests of the same page view.
+	PageViewID string `json:"page_view_id"`
+	// PublisherID identifies the publisher of the inventory, checked against the publishers of the deals.
+	PublisherID string `json:"publisher_id"`
+	// DealIDs reserves the delivery to the campaigns of these deals, at the deal price.
+	DealIDs []string `json:"deal_ids"`
+	// PrivateAuction answers no match instead of falling back to the open auction
+	// when no campaign of the deals can be delivered.
+	PrivateAuction bool `json:"private_auction"`
 }
 
 type CampaignMatchResponse struct {
-	CampaignID string          `json:"campaign_id"`
-	Bid        decimal.Decimal `json:"bid"`
+	CampaignID    string          `json:"campaign_id"`
+	Bid           decimal.Decimal `json:"bid"`
+	EffectiveBid  decimal.Decimal `json:"effective_bid"`
+	PricingModel  string          `json:"pricing_model"`
+	Priority      string          `json:"priority"`
+	ClearingPrice decimal.Decimal `json:"clearing_price"`
+	// ClearingCPM is the clearing price per thousand deliveries.
+	ClearingCPM decimal.Decimal `json:"clearing_cpm"`
+	DealID      string          `json:"deal_id,omitempty"`
+	// Creative is the approved creative to render. It links to the click_url of the delivery,
+	// which redirects to the click_url of the creative.
+	Creative CreativeResponse `json:"creative"`
+	// Native is the Native 1.2 response of the native creatives, linking to the click_url and
+	// tracking the impression_url of the delivery.
+	Native *OpenRTBNativeResponse `json:"native,omitempty"`
+	// ImpressionURL confirms the delivery when called as the ad is rendered, ClickURL records
+	// the clicks and redirects to the campaign landing page, and ConversionURL records the conversions.
+	ImpressionURL string `json:"impression_url"`
+	ClickURL      string `json:"click_url"`
+	ConversionURL string `json:"conversion_url"`
+}
+
+type CampaignsMatchResponse struct {
+	Campaigns []CampaignMatchResponse `json:"campaigns"`
 }
 
+// maxSlots is the maximum number of campaigns delivered by a single request.
+const maxSlots = 10
+
 // @Summary      Match a campaign
 // @Description  Matches a campaign based on country, device, and OS, after validating consent.
+// @Description  The cost of the delivery at the clearing price, not the bid, is reserved from the campaign
+// @Description  budget: the clearing price for cpd, a thousandth of it for cpm and nothing for cpc and cpa.
+// @Description  Calling the impression_url of the delivery confirms the charge when the ad is rendered,
+// @Description  otherwise the cost is released back to the budget once the reservation expires.
+// @Description  The click_url and conversion_url of the delivery record its clicks and conversions.
+// @Description  Campaigns compete with their effective bid, their bid adjusted by their bid modifiers.
+// @Description  Campaigns bidding below the request bid floor or the floor rules are skipped.
+// @Description  When slots is informed, up to that many distinct campaigns are delivered in bid order,
+// @Description  answered as CampaignsMatchResponse, and each winner pays the bid of the next one.
+// @Description  When user_id is informed, campaigns that reached their frequency or recency cap for the user
+// @Description  are skipped. Sequenced campaigns are only delivered to users who saw the campaign they follow.
+// @Description  Campaigns competing with the category of another winner, or of a campaign delivered earlier
+// @Description  in the page view of page_view_id, are skipped.
+// @Description  Guaranteed campaigns win before the auction at their bid, and house campaigns fill for free
+// @Description  the slots no other campaign won. Impression goal campaigns ahead of schedule are skipped
+// @Description  more and more often, so their deliveries are spread until they expire.
+// @Description  When deal_ids is informed, the campaigns of the deals allowing publisher_id compete first and
+// @Description  pay the deal price, answered with their deal_id. The open auction is held when none of them
+// @Description  can be delivered, unless private_auction is set.
+// @Description  When formats or sizes is informed, only the campaigns with an approved creative of one of the formats,
+// @Description  banners fitting one of the sizes, are delivered with the first such creative. Otherwise only the campaigns
+// @Description  with an approved banner are, with the first one. Native creatives are answered with their Native 1.2 response.
 // @Tags         campaigns
 // @Accept       json
 // @Produce      json
 // @Param        X-Consent-String  header  string                  true  "Consent string"
 // @Param        request           body    CampaignMatchRequest     true  "Campaign match request"
 // @Success      200               {object} CampaignMatchResponse   "Matched campaign"
+// @Success      200               {object} CampaignsMatchResponse  "Matched campaigns, when slots is informed"
 // @Success      204               "No matching campaign found"
+// @Header       204               {string} X-No-Match-Reason "no_active_campaign, below_floor, frequency_capped, recency_capped, out_of_sequence, competing_category, paced, no_creative or no_deal"
 // @Failure      400               {object} pkg.ErrorResp
 // @Failure      500               {object} pkg.ErrorResp
-// @Router       /campaigns/match [post]
+// @Router       /deliver [post]
 func (h *CampaignsHandler) match(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	consentToken := r.Header.Get("X-Consent-String")
-	if consentToken == "" {
-		pkg.BadRequestResponse(w, r, "missing header X-Consent-String")
+	if !checkConsent(w, r) {
 		return
 	}
-	consentVendorID := 1231 // let's assume Opti Digital Vendor ID is 1231 for now
-	hasConsent, err := pkg.CheckConsent(consentToken, consentVendorID)
+
+	input := CampaignMatchRequest{}
+	err := json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
-		pkg.ErrorResponse(w, r, err)
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid request payload: %v", err))
 		return
 	}
-	if !hasConsent {
-		pkg.BadRequestResponse(w, r, "invalid consent token")
+
+	req, err := parseMatchRequest(input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, err.Error())
 		return
 	}
 
-	input := CampaignMatchRequest{}
-	err = json.NewDecoder(r.Body).Decode(&input)
+	result, err := h.UseCase.Match(ctx, req)
 	if err != nil {
-		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid request payload: %v", err))
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	if len(result.Matches) == 0 {
+		w.Header().Set("X-No-Match-Reason", string(result.NoMatchReason))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	campaigns := make([]CampaignMatchResponse, 0, len(result.Matches))
+	for _, m := range result.Matches {
+		tracking := h.Signer.NewTracking(m.ReservationID, m.ID, m.Creative.ID, m.ClearingPrice)
+		var native *OpenRTBNativeResponse
+		if m.Creative.Format == model.Native {
+			n := nativeMarkup(m.Creative, defaultNativeRequest,
+				h.Signer.TrackingURL("/t/click", tracking), h.Signer.TrackingURL("/t/imp", tracking))
+			native = &n
+		}
+		campaigns = append(campaigns, CampaignMatchResponse{
+			CampaignID:    m.ID,
+			Bid:           m.Bid,
+			EffectiveBid:  m.EffectiveBid,
+			PricingModel:  string(m.PricingModel),
+			Priority:      string(m.Priority),
+			ClearingPrice: m.ClearingPrice,
+			ClearingCPM:   m.ClearingCPM,
+			DealID:        m.DealID,
+			Creative:      newCreativeResponse(m.Creative),
+			Native:        native,
+			ImpressionURL: h.Signer.TrackingURL("/t/imp", tracking),
+			ClickURL:      h.Signer.TrackingURL("/t/click", tracking),
+			ConversionURL: h.Signer.TrackingURL("/t/conv", tracking),
+		})
+	}
+
+	if input.Slots == 0 {
+		pkg.JsonResponse(w, r, http.StatusOK, campaigns[0])
 		return
 	}
+	pkg.JsonResponse(w, r, http.StatusOK, CampaignsMatchResponse{Campaigns: campaigns})
+}
+
+// consentVendorID is the vendor whose consent the consent strings must grant.
+const consentVendorID = 1231 // let's assume Opti Digital Vendor ID is 1231 for now
+
+// checkConsent validates the consent string of the request, answering the error when it
+// is missing or does not grant consent.
+func checkConsent(w http.ResponseWriter, r *http.Request) bool {
+	consentToken := r.Header.Get("X-Consent-String")
+	if consentToken == "" {
+		pkg.BadRequestResponse(w, r, "missing header X-Consent-String")
+		return false
+	}
+	hasConsent, err := pkg.CheckConsent(consentToken, consentVendorID)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return false
+	}
+	if !hasConsent {
+		pkg.BadRequestResponse(w, r, "invalid consent token")
+		return false
+	}
+	return true
+}
 
+// parseMatchRequest validates the delivery request.
+func parseMatchRequest(input CampaignMatchRequest) (model.MatchRequest, error) {
 	country, ok := model.Countries[input.Country]
 	if !ok {
-		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid country: %v", input.Country))
-		return
+		return model.MatchRequest{}, fmt.Errorf("invalid country: %v", input.Country)
 	}
 
 	device, ok := model.Devices[input.Device]
 	if !ok {
-		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid device: %v", input.Device))
-		return
+		return model.MatchRequest{}, fmt.Errorf("invalid device: %v", input.Device)
 	}
 
 	os, ok := model.OperationalSystems[input.OS]
 	if !ok {
-		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid os: %v", input.OS))
-		return
+		return model.MatchRequest{}, fmt.Errorf("invalid os: %v", input.OS)
+	}
+
+	if input.BidFloor.IsNegative() {
+		return model.MatchRequest{}, fmt.Errorf("invalid bid_floor: %v", input.BidFloor)
 	}
 
-	campaignMatch, err := h.UseCase.Match(ctx, country, device, os)
+	if input.Slots < 0 || input.Slots > maxSlots {
+		return model.MatchRequest{}, fmt.Errorf("invalid slots: %v, must be between 0 and %d, 0 answering a single campaign",
+			input.Slots, maxSlots)
+	}
+
+	formats, err := parseFormats(input.Formats)
 	if err != nil {
-		pkg.ErrorResponse(w, r, err)
-		return
+		return model.MatchRequest{}, err
 	}
 
-	if campaignMatch != nil {
-		pkg.JsonResponse(w, r, http.StatusOK, CampaignMatchResponse{CampaignID: campaignMatch.ID, Bid: campaignMatch.Bid})
-		return
+	sizes, err := parseSizes(input.Sizes)
+	if err != nil {
+		return model.MatchRequest{}, err
 	}
-	w.WriteHeader(http.StatusNoContent)
+
+	return model.MatchRequest{
+		Targeting:         model.Targeting{Country: country, Device: device, OS: os},
+		BidFloor:          input.BidFloor,
+		Slots:             input.Slots,
+		Formats:           formats,
+		Sizes:             sizes,
+		UniqueAdvertisers: input.UniqueAdvertisers,
+		// the consent validated by the handler covers storing the deliveries of the user
+		UserID:         input.UserID,
+		PageViewID:     input.PageViewID,
+		Publisher:      input.PublisherID,
+		DealIDs:        input.DealIDs,
+		PrivateAuction: input.PrivateAuction,
+	}, nil
 }
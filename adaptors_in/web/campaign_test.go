@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"ad-campaign-delivery/model"
 	"ad-campaign-delivery/pkg"
@@ -17,17 +18,27 @@ import (
 
 func TestCampaignsHandler_Create(t *testing.T) {
 	tests := []struct {
-		name         string
-		input        CampaignCreateRequest
-		callCreate   bool
-		createErr    error
-		expectedCode int
-		expectedBody string
+		name          string
+		input         CampaignCreateRequest
+		callCreate    bool
+		createErr     error
+		wantPricing   model.PricingModel
+		wantPriority  model.Priority
+		wantModifiers model.BidModifiers
+		wantCap       model.FrequencyCap
+		wantRecency   time.Duration
+		wantSequence  model.Sequence
+		wantFormats   []model.CreativeFormat
+		wantSizes     []model.Size
+		expectedCode  int
+		expectedBody  string
 	}{
 		{
 			name: "successful creation",
 			input: CampaignCreateRequest{
 				ID:         "camp123",
+				Advertiser: "acme",
+				Category:   "automotive",
 				Country:    "FR",
 				Device:     "mobile",
 				OS:         "android",
@@ -37,8 +48,344 @@ func TestCampaignsHandler_Create(t *testing.T) {
 			},
 			callCreate:   true,
 			createErr:    nil,
+			wantPricing:  model.CPD,
+			wantPriority: model.Standard,
 			expectedCode: http.StatusCreated,
 		},
+		{
+			name: "successful creation with cpm pricing model",
+			input: CampaignCreateRequest{
+				ID:           "camp123",
+				Country:      "FR",
+				Device:       "mobile",
+				OS:           "android",
+				Bid:          decimal.NewFromFloat(1.5),
+				PricingModel: "cpm",
+				Budget:       decimal.NewFromFloat(100),
+			},
+			callCreate:   true,
+			wantPricing:  model.CPM,
+			wantPriority: model.Standard,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "successful creation for any device and os with bid modifiers",
+			input: CampaignCreateRequest{
+				ID:      "camp123",
+				Country: "FR",
+				Bid:     decimal.NewFromFloat(1.5),
+				BidModifiers: BidModifiersRequest{
+					OS:   map[string]decimal.Decimal{"android": decimal.NewFromFloat(1.2)},
+					Hour: map[int]decimal.Decimal{20: decimal.NewFromFloat(0.8)},
+				},
+				Budget: decimal.NewFromFloat(100),
+			},
+			callCreate:   true,
+			wantPricing:  model.CPD,
+			wantPriority: model.Standard,
+			wantModifiers: model.BidModifiers{
+				OS:   map[model.OS]decimal.Decimal{model.Android: decimal.NewFromFloat(1.2)},
+				Hour: map[int]decimal.Decimal{20: decimal.NewFromFloat(0.8)},
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "successful creation with frequency cap",
+			input: CampaignCreateRequest{
+				ID:           "camp123",
+				Bid:          decimal.NewFromFloat(1.5),
+				Budget:       decimal.NewFromFloat(100),
+				FrequencyCap: FrequencyCapRequest{Impressions: 3, Period: "24h"},
+			},
+			callCreate:   true,
+			wantPricing:  model.CPD,
+			wantPriority: model.Standard,
+			wantCap:      model.FrequencyCap{Impressions: 3, Period: 24 * time.Hour},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "successful creation of a sequenced campaign with recency cap",
+			input: CampaignCreateRequest{
+				ID:         "chapter2",
+				Bid:        decimal.NewFromFloat(1.5),
+				Budget:     decimal.NewFromFloat(100),
+				RecencyCap: "10m",
+				Sequence:   SequenceRequest{After: "chapter1", MinDelay: "1h"},
+			},
+			callCreate:   true,
+			wantPricing:  model.CPD,
+			wantPriority: model.Standard,
+			wantRecency:  10 * time.Minute,
+			wantSequence: model.Sequence{After: "chapter1", MinDelay: time.Hour},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "invalid recency cap",
+			input: CampaignCreateRequest{
+				ID:         "camp123",
+				Bid:        decimal.NewFromFloat(1.5),
+				Budget:     decimal.NewFromFloat(100),
+				RecencyCap: "-10m",
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid recency_cap: -10m",
+		},
+		{
+			name: "invalid sequence following itself",
+			input: CampaignCreateRequest{
+				ID:       "camp123",
+				Bid:      decimal.NewFromFloat(1.5),
+				Budget:   decimal.NewFromFloat(100),
+				Sequence: SequenceRequest{After: "camp123"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid sequence: campaign camp123 cannot follow itself",
+		},
+		{
+			name: "successful creation with click url",
+			input: CampaignCreateRequest{
+				ID:         "camp123",
+				Country:    "FR",
+				Device:     "mobile",
+				OS:         "android",
+				Bid:        decimal.NewFromFloat(1.5),
+				Budget:     decimal.NewFromFloat(100),
+				ClickURL:   "https://example.com/landing?utm_source=ads",
+				ActiveDays: 30,
+			},
+			callCreate:   true,
+			wantPricing:  model.CPD,
+			wantPriority: model.Standard,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "successful creation restricted to leaderboard and native slots",
+			input: CampaignCreateRequest{
+				ID:      "camp123",
+				Bid:     decimal.NewFromFloat(1.5),
+				Budget:  decimal.NewFromFloat(100),
+				Formats: []string{"banner", "native"},
+				Sizes:   []string{"728x90"},
+			},
+			callCreate:   true,
+			wantPricing:  model.CPD,
+			wantPriority: model.Standard,
+			wantFormats:  []model.CreativeFormat{model.Banner, model.Native},
+			wantSizes:    []model.Size{{Width: 728, Height: 90}},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "invalid format",
+			input: CampaignCreateRequest{
+				ID:      "camp123",
+				Bid:     decimal.NewFromFloat(1.5),
+				Budget:  decimal.NewFromFloat(100),
+				Formats: []string{"audio"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid format: audio",
+		},
+		{
+			name: "invalid size",
+			input: CampaignCreateRequest{
+				ID:     "camp123",
+				Bid:    decimal.NewFromFloat(1.5),
+				Budget: decimal.NewFromFloat(100),
+				Sizes:  []string{"728"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid size: 728, e.g. 300x250",
+		},
+		{
+			name: "invalid sizes without banner format",
+			input: CampaignCreateRequest{
+				ID:      "camp123",
+				Bid:     decimal.NewFromFloat(1.5),
+				Budget:  decimal.NewFromFloat(100),
+				Formats: []string{"video"},
+				Sizes:   []string{"728x90"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid sizes: [728x90], only banners have sizes",
+		},
+		{
+			name: "invalid click url",
+			input: CampaignCreateRequest{
+				ID:       "camp123",
+				Bid:      decimal.NewFromFloat(1.5),
+				Budget:   decimal.NewFromFloat(100),
+				ClickURL: "javascript:alert(1)",
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid click_url: javascript:alert(1)",
+		},
+		{
+			name: "invalid frequency cap period",
+			input: CampaignCreateRequest{
+				ID:           "camp123",
+				Bid:          decimal.NewFromFloat(1.5),
+				Budget:       decimal.NewFromFloat(100),
+				FrequencyCap: FrequencyCapRequest{Impressions: 3, Period: "a day"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `invalid frequency_cap: period \"a day\" must be a positive duration`,
+		},
+		{
+			name: "frequency cap period longer than the exposure ttl",
+			input: CampaignCreateRequest{
+				ID:           "camp123",
+				Bid:          decimal.NewFromFloat(1.5),
+				Budget:       decimal.NewFromFloat(100),
+				FrequencyCap: FrequencyCapRequest{Impressions: 3, Period: "720h"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `invalid frequency_cap: period \"720h\" is longer than the exposure ttl 168h0m0s`,
+		},
+		{
+			name: "recency cap longer than the exposure ttl",
+			input: CampaignCreateRequest{
+				ID:         "camp123",
+				Bid:        decimal.NewFromFloat(1.5),
+				Budget:     decimal.NewFromFloat(100),
+				RecencyCap: "200h",
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid recency_cap: 200h, longer than the exposure ttl 168h0m0s",
+		},
+		{
+			name: "sequence delay longer than the exposure ttl",
+			input: CampaignCreateRequest{
+				ID:       "camp123",
+				Bid:      decimal.NewFromFloat(1.5),
+				Budget:   decimal.NewFromFloat(100),
+				Sequence: SequenceRequest{After: "teaser", MinDelay: "336h"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `invalid sequence: min_delay \"336h\" is longer than the exposure ttl 168h0m0s`,
+		},
+		{
+			name: "invalid frequency cap impressions",
+			input: CampaignCreateRequest{
+				ID:           "camp123",
+				Bid:          decimal.NewFromFloat(1.5),
+				Budget:       decimal.NewFromFloat(100),
+				FrequencyCap: FrequencyCapRequest{Impressions: -1, Period: "24h"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid frequency_cap: impressions -1 must be positive",
+		},
+		{
+			name: "invalid bid modifier hour",
+			input: CampaignCreateRequest{
+				ID:      "camp123",
+				Country: "FR",
+				Bid:     decimal.NewFromFloat(1.5),
+				BidModifiers: BidModifiersRequest{
+					Hour: map[int]decimal.Decimal{24: decimal.NewFromFloat(0.8)},
+				},
+				Budget: decimal.NewFromFloat(100),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid bid_modifiers: hour 24: 0.8",
+		},
+		{
+			name: "invalid bid modifier value",
+			input: CampaignCreateRequest{
+				ID:      "camp123",
+				Country: "FR",
+				Bid:     decimal.NewFromFloat(1.5),
+				BidModifiers: BidModifiersRequest{
+					Device: map[string]decimal.Decimal{"mobile": decimal.Zero},
+				},
+				Budget: decimal.NewFromFloat(100),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid bid_modifiers: device mobile: 0",
+		},
+		{
+			name: "successful creation of a house campaign without budget",
+			input: CampaignCreateRequest{
+				ID:       "house1",
+				Bid:      decimal.NewFromFloat(1),
+				Priority: "house",
+			},
+			callCreate:   true,
+			wantPricing:  model.CPD,
+			wantPriority: model.House,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "invalid house campaign with budget",
+			input: CampaignCreateRequest{
+				ID:       "house1",
+				Bid:      decimal.NewFromFloat(1),
+				Priority: "house",
+				Budget:   decimal.NewFromFloat(100),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid budget: 100, house campaigns have no budget",
+		},
+		{
+			name: "successful creation of an impression goal campaign",
+			input: CampaignCreateRequest{
+				ID:             "goal1",
+				Bid:            decimal.NewFromFloat(2),
+				Priority:       "guaranteed",
+				ImpressionGoal: 10000,
+				ActiveDays:     30,
+			},
+			callCreate:   true,
+			wantPricing:  model.CPD,
+			wantPriority: model.Guaranteed,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "invalid impression goal campaign with budget",
+			input: CampaignCreateRequest{
+				ID:             "goal1",
+				Bid:            decimal.NewFromFloat(2),
+				Budget:         decimal.NewFromFloat(100),
+				ImpressionGoal: 10000,
+				ActiveDays:     30,
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid budget: 100, impression goal campaigns have no budget",
+		},
+		{
+			name: "invalid impression goal campaign without expiration",
+			input: CampaignCreateRequest{
+				ID:             "goal1",
+				Bid:            decimal.NewFromFloat(2),
+				ImpressionGoal: 10000,
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid active_days: 0, impression goal campaigns must expire",
+		},
+		{
+			name: "invalid priority",
+			input: CampaignCreateRequest{
+				ID:       "camp123",
+				Bid:      decimal.NewFromFloat(1.5),
+				Priority: "urgent",
+				Budget:   decimal.NewFromFloat(100),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid priority: urgent",
+		},
+		{
+			name: "invalid pricing model",
+			input: CampaignCreateRequest{
+				ID:           "camp123",
+				Country:      "FR",
+				Device:       "mobile",
+				OS:           "android",
+				Bid:          decimal.NewFromFloat(1.5),
+				PricingModel: "cpx",
+				Budget:       decimal.NewFromFloat(100),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid pricing_model: cpx",
+		},
 		{
 			name: "missing ID",
 			input: CampaignCreateRequest{
@@ -141,6 +488,8 @@ func TestCampaignsHandler_Create(t *testing.T) {
 			},
 			callCreate:   true,
 			createErr:    pkg.Errorf(pkg.ECONFLICT, "campaign with ID camp123 already exists"),
+			wantPricing:  model.CPD,
+			wantPriority: model.Standard,
 			expectedCode: http.StatusConflict,
 			expectedBody: "campaign with ID camp123 already exists",
 		},
@@ -151,16 +500,27 @@ func TestCampaignsHandler_Create(t *testing.T) {
 			campaignServiceMock := &ports_in.CampaignServiceMock{
 				CreateFunc: func(ctx context.Context, campaign model.Campaign, activeDays int) error {
 					assert.Equal(t, tt.input.ID, campaign.ID)
+					assert.Equal(t, tt.input.Advertiser, campaign.Advertiser)
+					assert.Equal(t, model.Category(tt.input.Category), campaign.Category)
 					assert.Equal(t, model.Countries[tt.input.Country], campaign.Country)
 					assert.Equal(t, model.Devices[tt.input.Device], campaign.Device)
 					assert.Equal(t, model.OperationalSystems[tt.input.OS], campaign.OS)
 					assert.True(t, tt.input.Bid.Equal(campaign.Bid))
+					assert.Equal(t, tt.wantPricing, campaign.PricingModel)
+					assert.Equal(t, tt.wantPriority, campaign.Priority)
+					assert.Equal(t, tt.wantModifiers, campaign.BidModifiers)
+					assert.Equal(t, tt.wantCap, campaign.FrequencyCap)
+					assert.Equal(t, tt.wantRecency, campaign.RecencyCap)
+					assert.Equal(t, tt.wantSequence, campaign.Sequence)
+					assert.Equal(t, tt.input.ClickURL, campaign.ClickURL)
+					assert.Equal(t, tt.wantFormats, campaign.Formats)
+					assert.Equal(t, tt.wantSizes, campaign.Sizes)
 					assert.True(t, tt.input.Budget.Equal(campaign.Budget))
 					assert.Equal(t, tt.input.ActiveDays, activeDays)
 					return tt.createErr
 				},
 			}
-			handler := CampaignsHandler{UseCase: campaignServiceMock}
+			handler := CampaignsHandler{UseCase: campaignServiceMock, ExposureTTL: 168 * time.Hour}
 
 			body, _ := json.Marshal(tt.input)
 			req := httptest.NewRequest(http.MethodPost, "/campaigns", bytes.NewBuffer(body))
@@ -169,6 +529,7 @@ func TestCampaignsHandler_Create(t *testing.T) {
 			handler.create(rec, req)
 
 			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.callCreate, len(campaignServiceMock.CreateCalls()) == 1)
 			if tt.expectedBody != "" {
 				assert.Contains(t, rec.Body.String(), tt.expectedBody)
 			}
@@ -189,20 +550,92 @@ func TestCampaignsHandler_Match(t *testing.T) {
 	//Valid TCF v2 format, but missing consent
 	missingConsentString := "COtybn4Otybn4AcABBENAPCIAEBAAECAAIAAAAAAAAAAAgAA.YAAAAAAAAAAA"
 
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("secret"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+	tracking1 := signer.NewTracking("res1", "camp123", "banner1", decimal.NewFromFloat(1.2))
+	tracking2 := signer.NewTracking("res2", "camp456", "banner2", decimal.NewFromFloat(1))
+	banner1 := model.Creative{ID: "banner1", CampaignID: "camp123", Format: model.Banner,
+		Size: model.Size{Width: 300, Height: 250}, ImageURL: "https://cdn.example.com/banner1.png"}
+	banner2 := model.Creative{ID: "banner2", CampaignID: "camp456", Format: model.Banner,
+		Size: model.Size{Width: 300, Height: 250}, ImageURL: "https://cdn.example.com/banner2.png"}
+	nativeTracking := signer.NewTracking("res1", "camp123", "infeed", decimal.NewFromFloat(2))
+
 	successfulMatch := `{
 	"campaign_id": "camp123",
-	"bid": "1.5"
+	"bid": "1.5",
+	"effective_bid": "1.8",
+	"pricing_model": "cpm",
+	"priority": "guaranteed",
+	"clearing_price": "1.2",
+	"clearing_cpm": "1.2",
+	"creative": {
+		"id": "banner1",
+		"format": "banner",
+		"width": 300,
+		"height": 250,
+		"image_url": "https://cdn.example.com/banner1.png"
+	},
+	"impression_url": "` + signer.TrackingURL("/t/imp", tracking1) + `",
+	"click_url": "` + signer.TrackingURL("/t/click", tracking1) + `",
+	"conversion_url": "` + signer.TrackingURL("/t/conv", tracking1) + `"
+}
+`
+	successfulSlotsMatch := `{
+	"campaigns": [
+		{
+			"campaign_id": "camp123",
+			"bid": "1.5",
+			"effective_bid": "1.5",
+			"pricing_model": "cpm",
+			"priority": "standard",
+			"clearing_price": "1.2",
+			"clearing_cpm": "0",
+			"creative": {
+				"id": "banner1",
+				"format": "banner",
+				"width": 300,
+				"height": 250,
+				"image_url": "https://cdn.example.com/banner1.png"
+			},
+			"impression_url": "` + signer.TrackingURL("/t/imp", tracking1) + `",
+			"click_url": "` + signer.TrackingURL("/t/click", tracking1) + `",
+			"conversion_url": "` + signer.TrackingURL("/t/conv", tracking1) + `"
+		},
+		{
+			"campaign_id": "camp456",
+			"bid": "1.2",
+			"effective_bid": "1.2",
+			"pricing_model": "cpc",
+			"priority": "standard",
+			"clearing_price": "1",
+			"clearing_cpm": "0",
+			"creative": {
+				"id": "banner2",
+				"format": "banner",
+				"width": 300,
+				"height": 250,
+				"image_url": "https://cdn.example.com/banner2.png"
+			},
+			"impression_url": "` + signer.TrackingURL("/t/imp", tracking2) + `",
+			"click_url": "` + signer.TrackingURL("/t/click", tracking2) + `",
+			"conversion_url": "` + signer.TrackingURL("/t/conv", tracking2) + `"
+		}
+	]
 }
 `
 	tests := []struct {
-		name              string
-		consentToken      string
-		input             CampaignMatchRequest
-		callMatch         bool
-		mockMatchResponse *model.BidLookup
-		mockMatchError    error
-		expectedCode      int
-		expectedBody      string
+		name            string
+		consentToken    string
+		input           CampaignMatchRequest
+		callMatch       bool
+		mockMatchResult model.MatchResult
+		mockMatchError  error
+		wantFormats     []model.CreativeFormat
+		wantSizes       []model.Size
+		expectedCode    int
+		expectedBody    string
+		expectedReason  string
 	}{
 		{
 			name:         "successful match",
@@ -213,13 +646,214 @@ func TestCampaignsHandler_Match(t *testing.T) {
 				OS:      "android",
 			},
 			callMatch: true,
-			mockMatchResponse: &model.BidLookup{
-				ID:  "camp123",
-				Bid: decimal.NewFromFloat(1.5),
-			},
+			mockMatchResult: model.MatchResult{Matches: []model.CampaignMatch{{
+				ID:            "camp123",
+				Bid:           decimal.NewFromFloat(1.5),
+				EffectiveBid:  decimal.NewFromFloat(1.8),
+				PricingModel:  model.CPM,
+				Priority:      model.Guaranteed,
+				ClearingPrice: decimal.NewFromFloat(1.2),
+				ClearingCPM:   decimal.NewFromFloat(1.2),
+				Creative:      banner1,
+				ReservationID: "res1",
+			}}},
 			expectedCode: http.StatusOK,
 			expectedBody: successfulMatch,
 		},
+		{
+			name:         "successful match of multiple slots",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country:           "FR",
+				Device:            "mobile",
+				OS:                "android",
+				Slots:             3,
+				UniqueAdvertisers: true,
+				PageViewID:        "view1",
+			},
+			callMatch: true,
+			mockMatchResult: model.MatchResult{Matches: []model.CampaignMatch{
+				{ID: "camp123", Bid: decimal.NewFromFloat(1.5), EffectiveBid: decimal.NewFromFloat(1.5),
+					PricingModel: model.CPM, Priority: model.Standard, ClearingPrice: decimal.NewFromFloat(1.2),
+					Creative: banner1, ReservationID: "res1"},
+				{ID: "camp456", Bid: decimal.NewFromFloat(1.2), EffectiveBid: decimal.NewFromFloat(1.2),
+					PricingModel: model.CPC, Priority: model.Standard, ClearingPrice: decimal.NewFromFloat(1),
+					Creative: banner2, ReservationID: "res2"},
+			}},
+			expectedCode: http.StatusOK,
+			expectedBody: successfulSlotsMatch,
+		},
+		{
+			name:         "successful match through a deal",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country:        "FR",
+				Device:         "mobile",
+				OS:             "android",
+				PublisherID:    "pub1",
+				DealIDs:        []string{"deal1", "deal2"},
+				PrivateAuction: true,
+			},
+			callMatch: true,
+			mockMatchResult: model.MatchResult{Matches: []model.CampaignMatch{{
+				ID:            "camp123",
+				Bid:           decimal.NewFromFloat(3),
+				EffectiveBid:  decimal.NewFromFloat(3),
+				PricingModel:  model.CPD,
+				Priority:      model.Standard,
+				ClearingPrice: decimal.NewFromFloat(2),
+				DealID:        "deal1",
+			}}},
+			expectedCode: http.StatusOK,
+			expectedBody: `"deal_id": "deal1"`,
+		},
+		{
+			name:         "successful match with the creative fitting the slot sizes",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country: "FR",
+				Device:  "mobile",
+				OS:      "android",
+				Sizes:   []string{"300x250", "320x50"},
+			},
+			callMatch: true,
+			mockMatchResult: model.MatchResult{Matches: []model.CampaignMatch{{
+				ID:            "camp123",
+				Bid:           decimal.NewFromFloat(3),
+				EffectiveBid:  decimal.NewFromFloat(3),
+				PricingModel:  model.CPD,
+				Priority:      model.Standard,
+				ClearingPrice: decimal.NewFromFloat(2),
+				Creative: model.Creative{ID: "mobile-banner", CampaignID: "camp123", Format: model.Banner,
+					Size: model.Size{Width: 320, Height: 50}, ImageURL: "https://cdn.example.com/banner.png"},
+				ReservationID: "res1",
+			}}},
+			wantSizes:    []model.Size{{Width: 300, Height: 250}, {Width: 320, Height: 50}},
+			expectedCode: http.StatusOK,
+			expectedBody: `"creative": {
+		"id": "mobile-banner",
+		"format": "banner",
+		"width": 320,
+		"height": 50,
+		"image_url": "https://cdn.example.com/banner.png"
+	},`,
+		},
+		{
+			name:         "successful match with the native response of the native creative",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country: "FR",
+				Device:  "mobile",
+				OS:      "android",
+				Formats: []string{"native"},
+			},
+			callMatch: true,
+			mockMatchResult: model.MatchResult{Matches: []model.CampaignMatch{{
+				ID:            "camp123",
+				Bid:           decimal.NewFromFloat(3),
+				EffectiveBid:  decimal.NewFromFloat(3),
+				PricingModel:  model.CPD,
+				Priority:      model.Standard,
+				ClearingPrice: decimal.NewFromFloat(2),
+				Creative: model.Creative{ID: "infeed", CampaignID: "camp123", Format: model.Native,
+					Native: model.NativeAd{Title: "Summer sale", CTA: "Shop now", SponsoredBy: "Example",
+						Image: model.NativeImage{URL: "https://cdn.example.com/sale.png", Size: model.Size{Width: 1200, Height: 627}}}},
+				ReservationID: "res1",
+			}}},
+			wantFormats:  []model.CreativeFormat{model.Native},
+			expectedCode: http.StatusOK,
+			expectedBody: `"native": {
+		"ver": "1.2",
+		"assets": [
+			{
+				"id": 1,
+				"title": {
+					"text": "Summer sale"
+				}
+			},
+			{
+				"id": 3,
+				"img": {
+					"url": "https://cdn.example.com/sale.png",
+					"w": 1200,
+					"h": 627
+				}
+			},
+			{
+				"id": 5,
+				"data": {
+					"value": "Shop now"
+				}
+			},
+			{
+				"id": 6,
+				"data": {
+					"value": "Example"
+				}
+			}
+		],
+		"link": {
+			"url": "` + signer.TrackingURL("/t/click", nativeTracking) + `"
+		},
+		"eventtrackers": [
+			{
+				"event": 1,
+				"method": 1,
+				"url": "` + signer.TrackingURL("/t/imp", nativeTracking) + `"
+			}
+		]
+	},`,
+		},
+		{
+			name:         "invalid formats",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country: "FR",
+				Device:  "mobile",
+				OS:      "android",
+				Formats: []string{"native", "audio"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid format: audio",
+		},
+		{
+			name:         "invalid sizes",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country: "FR",
+				Device:  "mobile",
+				OS:      "android",
+				Sizes:   []string{"300x250", "300by250"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid size: 300by250, e.g. 300x250",
+		},
+		{
+			name:         "invalid slots",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country: "FR",
+				Device:  "mobile",
+				OS:      "android",
+				Slots:   11,
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid slots: 11, must be between 0 and 10, 0 answering a single campaign",
+		},
+		{
+			name:         "success, every campaign capped for the user",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country: "FR",
+				Device:  "mobile",
+				OS:      "android",
+				UserID:  "user1",
+			},
+			callMatch:       true,
+			mockMatchResult: model.MatchResult{NoMatchReason: model.FrequencyCapped},
+			expectedCode:    http.StatusNoContent,
+			expectedReason:  "frequency_capped",
+		},
 		{
 			name:         "success, no match found",
 			consentToken: validConsentString,
@@ -228,9 +862,36 @@ func TestCampaignsHandler_Match(t *testing.T) {
 				Device:  "mobile",
 				OS:      "android",
 			},
-			callMatch:         true,
-			mockMatchResponse: nil,
-			expectedCode:      http.StatusNoContent,
+			callMatch:       true,
+			mockMatchResult: model.MatchResult{NoMatchReason: model.NoActiveCampaign},
+			expectedCode:    http.StatusNoContent,
+			expectedReason:  "no_active_campaign",
+		},
+		{
+			name:         "success, no bid clears the floor",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country:  "FR",
+				Device:   "mobile",
+				OS:       "android",
+				BidFloor: decimal.NewFromFloat(3),
+			},
+			callMatch:       true,
+			mockMatchResult: model.MatchResult{NoMatchReason: model.BelowFloor},
+			expectedCode:    http.StatusNoContent,
+			expectedReason:  "below_floor",
+		},
+		{
+			name:         "invalid bid floor",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country:  "FR",
+				Device:   "mobile",
+				OS:       "android",
+				BidFloor: decimal.NewFromFloat(-1),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid bid_floor: -1",
 		},
 		{
 			name:         "missing consent token",
@@ -316,12 +977,24 @@ func TestCampaignsHandler_Match(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			campaignServiceMock := &ports_in.CampaignServiceMock{
-				MatchFunc: func(ctx context.Context, country model.Country, device model.Device,
-					os model.OS) (*model.BidLookup, error) {
-					return tt.mockMatchResponse, tt.mockMatchError
+				MatchFunc: func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
+					assert.Equal(t, model.Countries[tt.input.Country], req.Country)
+					assert.Equal(t, model.Devices[tt.input.Device], req.Device)
+					assert.Equal(t, model.OperationalSystems[tt.input.OS], req.OS)
+					assert.True(t, tt.input.BidFloor.Equal(req.BidFloor))
+					assert.Equal(t, tt.input.Slots, req.Slots)
+					assert.Equal(t, tt.wantFormats, req.Formats)
+					assert.Equal(t, tt.wantSizes, req.Sizes)
+					assert.Equal(t, tt.input.UniqueAdvertisers, req.UniqueAdvertisers)
+					assert.Equal(t, tt.input.UserID, req.UserID)
+					assert.Equal(t, tt.input.PageViewID, req.PageViewID)
+					assert.Equal(t, tt.input.PublisherID, req.Publisher)
+					assert.Equal(t, tt.input.DealIDs, req.DealIDs)
+					assert.Equal(t, tt.input.PrivateAuction, req.PrivateAuction)
+					return tt.mockMatchResult, tt.mockMatchError
 				},
 			}
-			handler := CampaignsHandler{UseCase: campaignServiceMock}
+			handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
 
 			body, _ := json.Marshal(tt.input)
 			req := httptest.NewRequest(http.MethodPost, "/deliver", bytes.NewBuffer(body))
@@ -333,9 +1006,11 @@ func TestCampaignsHandler_Match(t *testing.T) {
 			handler.match(rec, req)
 
 			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.callMatch, len(campaignServiceMock.MatchCalls()) == 1)
 			if tt.expectedBody != "" {
 				assert.Contains(t, rec.Body.String(), tt.expectedBody)
 			}
+			assert.Equal(t, tt.expectedReason, rec.Header().Get("X-No-Match-Reason"))
 		})
 	}
 }
@@ -0,0 +1,530 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+)
+
+// CreativeRequest is a banner image or an HTML snippet of the given size, in pixels, a video
+// or native assets.
+type CreativeRequest struct {
+	ID string `json:"id"`
+	// Format is banner, the default, video or native.
+	Format string `json:"format"`
+	// Width and Height are the size of the banners, videos being sized by their media files.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// ImageURL and HTML are exclusive: the banner is either an image or an HTML snippet.
+	ImageURL string         `json:"image_url"`
+	HTML     string         `json:"html"`
+	Video    *VideoRequest  `json:"video"`
+	Native   *NativeRequest `json:"native"`
+	// ClickURL is the click-through URL of the creative, the click_url of the campaign when empty.
+	ClickURL string `json:"click_url"`
+}
+
+// VideoRequest is a linear video of the given duration, e.g. 15s, encoded in each of its media files.
+type VideoRequest struct {
+	Duration   string             `json:"duration"`
+	MediaFiles []MediaFileRequest `json:"media_files"`
+	// TrackingEvents lists the third-party URLs called on each event of the playback, by VAST event name,
+	// e.g. {"complete": ["https://tracker.example.com/complete"]}.
+	TrackingEvents map[string][]string `json:"tracking_events,omitempty"`
+}
+
+// MediaFileRequest is an encoding of the video, whose type is its MIME type, e.g. video/mp4,
+// and whose bitrate is in kbit/s.
+type MediaFileRequest struct {
+	URL     string `json:"url"`
+	Type    string `json:"type"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Bitrate int    `json:"bitrate,omitempty"`
+}
+
+// NativeRequest holds the assets of a native creative, rendered by the publisher. The title, of at
+// most 90 characters, the main image and the advertiser it is sponsored by, of at most 25 characters,
+// are required. The description is at most 200 characters long, and the call to action 15.
+type NativeRequest struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	Icon        *NativeImageRequest `json:"icon,omitempty"`
+	Image       *NativeImageRequest `json:"image"`
+	CTA         string              `json:"cta,omitempty"`
+	SponsoredBy string              `json:"sponsored_by"`
+}
+
+// NativeImageRequest is an image asset of a native creative, of the given size in pixels.
+type NativeImageRequest struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// the maximum lengths of the text assets of the native creatives, in characters
+const (
+	maxNativeTitle       = 90
+	maxNativeDescription = 200
+	maxNativeCTA         = 15
+	maxNativeSponsoredBy = 25
+)
+
+type CreativeResponse struct {
+	ID       string         `json:"id"`
+	Format   string         `json:"format"`
+	Width    int            `json:"width,omitempty"`
+	Height   int            `json:"height,omitempty"`
+	ImageURL string         `json:"image_url,omitempty"`
+	HTML     string         `json:"html,omitempty"`
+	Video    *VideoRequest  `json:"video,omitempty"`
+	Native   *NativeRequest `json:"native,omitempty"`
+	ClickURL string         `json:"click_url,omitempty"`
+	// Review is only listed in the creatives of the campaign, the deliveries being of approved creatives.
+	Review *ReviewResponse `json:"review,omitempty"`
+}
+
+// ReviewResponse is the review state of the creative, pending, approved or rejected, with the reason
+// of its rejection and when it was reviewed.
+type ReviewResponse struct {
+	State      string     `json:"state"`
+	Reason     string     `json:"reason,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// CreativeRejectRequest holds the reason of the rejection of the creative, told to the advertiser.
+type CreativeRejectRequest struct {
+	Reason string `json:"reason"`
+}
+
+type CreativesResponse struct {
+	Creatives []CreativeResponse `json:"creatives"`
+}
+
+// @Summary      Add a creative to a campaign
+// @Description  Adds a banner image, or an HTML snippet, of the given size to the campaign, a video whose
+// @Description  media files are served by /deliver/vast, or native assets. Deliveries requesting slot sizes are made with the first
+// @Description  banner of the campaign fitting one of them, in creation order, and only to campaigns having one.
+// @Description  Clicks are redirected to the click_url of the creative, or of the campaign when it has none.
+// @Description  The creative is pending, and not delivered, until approved by its review.
+// @Tags         creatives
+// @Accept       json
+// @Param        id       path  string           true  "Campaign ID"
+// @Param        request  body  CreativeRequest  true  "Creative request"
+// @Success      201      "Creative added (no content)"
+// @Failure      400      {object}  pkg.ErrorResp
+// @Failure      404      {object}  pkg.ErrorResp
+// @Failure      409      {object}  pkg.ErrorResp
+// @Failure      500      {object}  pkg.ErrorResp
+// @Router       /campaigns/{id}/creatives [post]
+func (h *CampaignsHandler) addCreative(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input := CreativeRequest{}
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid request payload: %v", err))
+		return
+	}
+
+	creative, err := parseCreative(input, r.PathValue("id"))
+	if err != nil {
+		pkg.BadRequestResponse(w, r, err.Error())
+		return
+	}
+
+	err = h.UseCase.AddCreative(ctx, creative)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// @Summary      List the creatives of a campaign
+// @Description  Returns the creatives of the campaign in creation order, the order they are selected in.
+// @Tags         creatives
+// @Produce      json
+// @Param        id   path      string             true  "Campaign ID"
+// @Success      200  {object}  CreativesResponse  "Creatives of the campaign"
+// @Failure      404  {object}  pkg.ErrorResp
+// @Failure      500  {object}  pkg.ErrorResp
+// @Router       /campaigns/{id}/creatives [get]
+func (h *CampaignsHandler) listCreatives(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	creatives, err := h.UseCase.ListCreatives(ctx, r.PathValue("id"))
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	response := CreativesResponse{Creatives: make([]CreativeResponse, 0, len(creatives))}
+	for _, c := range creatives {
+		creative := newCreativeResponse(c)
+		creative.Review = newReviewResponse(c.Review)
+		response.Creatives = append(response.Creatives, creative)
+	}
+	pkg.JsonResponse(w, r, http.StatusOK, response)
+}
+
+// @Summary      Remove a creative from a campaign
+// @Description  Removes the creative, which is no longer delivered. The clicks of the deliveries already made
+// @Description  with it are redirected to the click_url of the campaign.
+// @Tags         creatives
+// @Param        id           path  string  true  "Campaign ID"
+// @Param        creative_id  path  string  true  "Creative ID"
+// @Success      204  "Creative removed (no content)"
+// @Failure      404  {object}  pkg.ErrorResp
+// @Failure      500  {object}  pkg.ErrorResp
+// @Router       /campaigns/{id}/creatives/{creative_id} [delete]
+func (h *CampaignsHandler) removeCreative(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := h.UseCase.RemoveCreative(ctx, r.PathValue("id"), r.PathValue("creative_id"))
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Approve a creative
+// @Description  Approves the creative for delivery after its review, including a creative rejected before.
+// @Description  Campaigns are only delivered once one of their creatives is approved.
+// @Tags         creatives
+// @Param        id           path  string  true  "Campaign ID"
+// @Param        creative_id  path  string  true  "Creative ID"
+// @Success      204  "Creative approved (no content)"
+// @Failure      404  {object}  pkg.ErrorResp
+// @Failure      500  {object}  pkg.ErrorResp
+// @Router       /campaigns/{id}/creatives/{creative_id}/approve [post]
+func (h *CampaignsHandler) approveCreative(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := h.UseCase.ReviewCreative(ctx, r.PathValue("id"), r.PathValue("creative_id"),
+		model.Review{State: model.Approved})
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Reject a creative
+// @Description  Rejects the creative after its review for the given reason, told to the advertiser in the
+// @Description  creatives of the campaign. Rejected creatives are no longer delivered, until approved again.
+// @Tags         creatives
+// @Accept       json
+// @Param        id           path  string                 true  "Campaign ID"
+// @Param        creative_id  path  string                 true  "Creative ID"
+// @Param        request      body  CreativeRejectRequest  true  "Rejection request"
+// @Success      204  "Creative rejected (no content)"
+// @Failure      400  {object}  pkg.ErrorResp
+// @Failure      404  {object}  pkg.ErrorResp
+// @Failure      500  {object}  pkg.ErrorResp
+// @Router       /campaigns/{id}/creatives/{creative_id}/reject [post]
+func (h *CampaignsHandler) rejectCreative(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input := CreativeRejectRequest{}
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid request payload: %v", err))
+		return
+	}
+
+	if strings.TrimSpace(input.Reason) == "" {
+		pkg.BadRequestResponse(w, r, "missing reason")
+		return
+	}
+
+	err = h.UseCase.ReviewCreative(ctx, r.PathValue("id"), r.PathValue("creative_id"),
+		model.Review{State: model.Rejected, Reason: input.Reason})
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseCreative validates the creative of the campaign.
+func parseCreative(input CreativeRequest, campaignID string) (model.Creative, error) {
+	if len(input.ID) == 0 {
+		return model.Creative{}, fmt.Errorf("missing creative ID")
+	}
+
+	format := model.Banner
+	if input.Format != "" {
+		var ok bool
+		format, ok = model.CreativeFormats[input.Format]
+		if !ok {
+			return model.Creative{}, fmt.Errorf("invalid format: %v", input.Format)
+		}
+	}
+
+	if input.ClickURL != "" && !isWebURL(input.ClickURL) {
+		return model.Creative{}, fmt.Errorf("invalid click_url: %v", input.ClickURL)
+	}
+
+	creative := model.Creative{
+		ID:         input.ID,
+		CampaignID: campaignID,
+		Format:     format,
+		ClickURL:   input.ClickURL,
+	}
+
+	hasBanner := input.Width != 0 || input.Height != 0 || input.ImageURL != "" || input.HTML != ""
+
+	switch format {
+	case model.Video:
+		if hasBanner || input.Native != nil {
+			return model.Creative{}, fmt.Errorf("invalid creative: video creatives only have a video")
+		}
+		if input.Video == nil {
+			return model.Creative{}, fmt.Errorf("missing video")
+		}
+		video, err := parseVideo(*input.Video)
+		if err != nil {
+			return model.Creative{}, fmt.Errorf("invalid video: %v", err)
+		}
+		creative.Video = video
+		return creative, nil
+	case model.Native:
+		if hasBanner || input.Video != nil {
+			return model.Creative{}, fmt.Errorf("invalid creative: native creatives only have native assets")
+		}
+		if input.Native == nil {
+			return model.Creative{}, fmt.Errorf("missing native")
+		}
+		native, err := parseNative(*input.Native)
+		if err != nil {
+			return model.Creative{}, fmt.Errorf("invalid native: %v", err)
+		}
+		creative.Native = native
+		return creative, nil
+	}
+
+	if input.Video != nil || input.Native != nil {
+		return model.Creative{}, fmt.Errorf("invalid creative: banner creatives have no video or native assets")
+	}
+
+	if input.Width <= 0 || input.Height <= 0 {
+		return model.Creative{}, fmt.Errorf("invalid size: %dx%d", input.Width, input.Height)
+	}
+
+	switch {
+	case input.ImageURL == "" && input.HTML == "":
+		return model.Creative{}, fmt.Errorf("missing image_url or html")
+	case input.ImageURL != "" && input.HTML != "":
+		return model.Creative{}, fmt.Errorf("invalid creative: image_url and html are exclusive")
+	case input.ImageURL != "" && !isWebURL(input.ImageURL):
+		return model.Creative{}, fmt.Errorf("invalid image_url: %v", input.ImageURL)
+	}
+
+	creative.Size = model.Size{Width: input.Width, Height: input.Height}
+	creative.ImageURL = input.ImageURL
+	creative.HTML = input.HTML
+	return creative, nil
+}
+
+// parseVideo validates the duration, the media files and the tracking events of the video.
+func parseVideo(input VideoRequest) (model.LinearVideo, error) {
+	duration, err := time.ParseDuration(input.Duration)
+	if err != nil || duration <= 0 {
+		return model.LinearVideo{}, fmt.Errorf("duration %q must be a positive duration, e.g. 15s", input.Duration)
+	}
+
+	if len(input.MediaFiles) == 0 {
+		return model.LinearVideo{}, fmt.Errorf("missing media_files")
+	}
+	mediaFiles := make([]model.MediaFile, 0, len(input.MediaFiles))
+	for _, f := range input.MediaFiles {
+		if !isWebURL(f.URL) {
+			return model.LinearVideo{}, fmt.Errorf("media file url %q must be an http or https URL", f.URL)
+		}
+		if !strings.HasPrefix(f.Type, "video/") && f.Type != "application/x-mpegURL" {
+			return model.LinearVideo{}, fmt.Errorf("media file type %q must be a video MIME type, e.g. video/mp4", f.Type)
+		}
+		if f.Width <= 0 || f.Height <= 0 || f.Bitrate < 0 {
+			return model.LinearVideo{}, fmt.Errorf("media file %s: invalid size %dx%d or bitrate %d",
+				f.URL, f.Width, f.Height, f.Bitrate)
+		}
+		mediaFiles = append(mediaFiles, model.MediaFile{URL: f.URL, MIMEType: f.Type,
+			Width: f.Width, Height: f.Height, Bitrate: f.Bitrate})
+	}
+
+	var trackingEvents map[model.EventType][]string
+	for name, urls := range input.TrackingEvents {
+		event, ok := model.VideoEvents[name]
+		if !ok {
+			return model.LinearVideo{}, fmt.Errorf("unknown tracking event %q", name)
+		}
+		for _, u := range urls {
+			if !isWebURL(u) {
+				return model.LinearVideo{}, fmt.Errorf("tracking event %s url %q must be an http or https URL", name, u)
+			}
+		}
+		if trackingEvents == nil {
+			trackingEvents = map[model.EventType][]string{}
+		}
+		trackingEvents[event] = urls
+	}
+
+	return model.LinearVideo{Duration: duration, MediaFiles: mediaFiles, TrackingEvents: trackingEvents}, nil
+}
+
+// parseNative validates the assets of the native creative.
+func parseNative(input NativeRequest) (model.NativeAd, error) {
+	texts := []struct {
+		name     string
+		value    string
+		max      int
+		required bool
+	}{
+		{name: "title", value: input.Title, max: maxNativeTitle, required: true},
+		{name: "description", value: input.Description, max: maxNativeDescription},
+		{name: "cta", value: input.CTA, max: maxNativeCTA},
+		{name: "sponsored_by", value: input.SponsoredBy, max: maxNativeSponsoredBy, required: true},
+	}
+	for _, text := range texts {
+		if text.required && strings.TrimSpace(text.value) == "" {
+			return model.NativeAd{}, fmt.Errorf("missing %s", text.name)
+		}
+		if !fitsLen(text.value, text.max) {
+			return model.NativeAd{}, fmt.Errorf("%s must be at most %d characters", text.name, text.max)
+		}
+	}
+
+	if input.Image == nil {
+		return model.NativeAd{}, fmt.Errorf("missing image")
+	}
+	image, err := parseNativeImage("image", *input.Image)
+	if err != nil {
+		return model.NativeAd{}, err
+	}
+	var icon model.NativeImage
+	if input.Icon != nil {
+		icon, err = parseNativeImage("icon", *input.Icon)
+		if err != nil {
+			return model.NativeAd{}, err
+		}
+	}
+
+	return model.NativeAd{
+		Title:       input.Title,
+		Description: input.Description,
+		Icon:        icon,
+		Image:       image,
+		CTA:         input.CTA,
+		SponsoredBy: input.SponsoredBy,
+	}, nil
+}
+
+// parseNativeImage validates the URL and the size of the image asset of the given name.
+func parseNativeImage(name string, input NativeImageRequest) (model.NativeImage, error) {
+	if !isWebURL(input.URL) {
+		return model.NativeImage{}, fmt.Errorf("%s url %q must be an http or https URL", name, input.URL)
+	}
+	if input.Width <= 0 || input.Height <= 0 {
+		return model.NativeImage{}, fmt.Errorf("%s: invalid size %dx%d", name, input.Width, input.Height)
+	}
+	return model.NativeImage{URL: input.URL, Size: model.Size{Width: input.Width, Height: input.Height}}, nil
+}
+
+// parseFormats validates the creative formats of a slot or a campaign.
+func parseFormats(input []string) ([]model.CreativeFormat, error) {
+	var formats []model.CreativeFormat
+	for _, value := range input {
+		format, ok := model.CreativeFormats[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid format: %v", value)
+		}
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+// parseSizes validates the slot sizes, each formatted as widthxheight, e.g. 300x250.
+func parseSizes(input []string) ([]model.Size, error) {
+	var sizes []model.Size
+	for _, value := range input {
+		width, height, _ := strings.Cut(value, "x")
+		w, errW := strconv.Atoi(width)
+		h, errH := strconv.Atoi(height)
+		if errW != nil || errH != nil || w <= 0 || h <= 0 {
+			return nil, fmt.Errorf("invalid size: %v, e.g. 300x250", value)
+		}
+		sizes = append(sizes, model.Size{Width: w, Height: h})
+	}
+	return sizes, nil
+}
+
+// isWebURL tells whether the value is an absolute http or https URL.
+func isWebURL(value string) bool {
+	u, err := url.ParseRequestURI(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func newCreativeResponse(c model.Creative) CreativeResponse {
+	response := CreativeResponse{
+		ID:       c.ID,
+		Format:   string(c.Format),
+		Width:    c.Size.Width,
+		Height:   c.Size.Height,
+		ImageURL: c.ImageURL,
+		HTML:     c.HTML,
+		ClickURL: c.ClickURL,
+	}
+	if c.Format == model.Video {
+		video := VideoRequest{Duration: c.Video.Duration.String()}
+		for _, f := range c.Video.MediaFiles {
+			video.MediaFiles = append(video.MediaFiles, MediaFileRequest{URL: f.URL, Type: f.MIMEType,
+				Width: f.Width, Height: f.Height, Bitrate: f.Bitrate})
+		}
+		for event, urls := range c.Video.TrackingEvents {
+			if video.TrackingEvents == nil {
+				video.TrackingEvents = map[string][]string{}
+			}
+			video.TrackingEvents[string(event)] = urls
+		}
+		response.Video = &video
+	}
+	if c.Format == model.Native {
+		native := NativeRequest{
+			Title:       c.Native.Title,
+			Description: c.Native.Description,
+			Image:       newNativeImageResponse(c.Native.Image),
+			CTA:         c.Native.CTA,
+			SponsoredBy: c.Native.SponsoredBy,
+		}
+		if c.Native.Icon.URL != "" {
+			native.Icon = newNativeImageResponse(c.Native.Icon)
+		}
+		response.Native = &native
+	}
+	return response
+}
+
+func newReviewResponse(review model.Review) *ReviewResponse {
+	response := &ReviewResponse{State: string(review.State), Reason: review.Reason}
+	if !review.ReviewedAt.IsZero() {
+		response.ReviewedAt = &review.ReviewedAt
+	}
+	return response
+}
+
+func newNativeImageResponse(image model.NativeImage) *NativeImageRequest {
+	return &NativeImageRequest{URL: image.URL, Width: image.Size.Width, Height: image.Size.Height}
+}
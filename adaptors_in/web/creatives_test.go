@@ -0,0 +1,467 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/ports_in"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignsHandler_AddCreative(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        CreativeRequest
+		callAdd      bool
+		wantCreative model.Creative
+		addErr       error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "banner image added",
+			input: CreativeRequest{ID: "banner", Width: 300, Height: 250,
+				ImageURL: "https://cdn.example.com/banner.png", ClickURL: "https://example.com/landing"},
+			callAdd: true,
+			wantCreative: model.Creative{ID: "banner", CampaignID: "camp123", Format: model.Banner, Size: model.Size{Width: 300, Height: 250},
+				ImageURL: "https://cdn.example.com/banner.png", ClickURL: "https://example.com/landing"},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:    "html snippet added",
+			input:   CreativeRequest{ID: "snippet", Width: 728, Height: 90, HTML: "<div>ad</div>"},
+			callAdd: true,
+			wantCreative: model.Creative{ID: "snippet", CampaignID: "camp123", Format: model.Banner, Size: model.Size{Width: 728, Height: 90},
+				HTML: "<div>ad</div>"},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "video added",
+			input: CreativeRequest{ID: "preroll", Format: "video", Video: &VideoRequest{Duration: "15s",
+				MediaFiles: []MediaFileRequest{{URL: "https://cdn.example.com/preroll.mp4", Type: "video/mp4",
+					Width: 1280, Height: 720, Bitrate: 2500}},
+				TrackingEvents: map[string][]string{"complete": {"https://tracker.example.com/complete"}}}},
+			callAdd: true,
+			wantCreative: model.Creative{ID: "preroll", CampaignID: "camp123", Format: model.Video,
+				Video: model.LinearVideo{Duration: 15 * time.Second,
+					MediaFiles: []model.MediaFile{{URL: "https://cdn.example.com/preroll.mp4", MIMEType: "video/mp4",
+						Width: 1280, Height: 720, Bitrate: 2500}},
+					TrackingEvents: map[model.EventType][]string{model.Complete: {"https://tracker.example.com/complete"}}}},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "invalid format",
+			input:        CreativeRequest{ID: "jingle", Format: "audio", HTML: "<div>ad</div>"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid format: audio",
+		},
+		{
+			name:         "video without video",
+			input:        CreativeRequest{ID: "preroll", Format: "video"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "missing video",
+		},
+		{
+			name: "video with an image",
+			input: CreativeRequest{ID: "preroll", Format: "video", ImageURL: "https://cdn.example.com/banner.png",
+				Video: &VideoRequest{Duration: "15s"}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid creative: video creatives only have a video",
+		},
+		{
+			name: "video without duration",
+			input: CreativeRequest{ID: "preroll", Format: "video", Video: &VideoRequest{
+				MediaFiles: []MediaFileRequest{{URL: "https://cdn.example.com/preroll.mp4", Type: "video/mp4",
+					Width: 1280, Height: 720}}}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `invalid video: duration \"\" must be a positive duration, e.g. 15s`,
+		},
+		{
+			name:         "video without media file",
+			input:        CreativeRequest{ID: "preroll", Format: "video", Video: &VideoRequest{Duration: "15s"}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid video: missing media_files",
+		},
+		{
+			name: "media file of another type",
+			input: CreativeRequest{ID: "preroll", Format: "video", Video: &VideoRequest{Duration: "15s",
+				MediaFiles: []MediaFileRequest{{URL: "https://cdn.example.com/preroll.png", Type: "image/png",
+					Width: 1280, Height: 720}}}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `invalid video: media file type \"image/png\" must be a video MIME type`,
+		},
+		{
+			name: "unknown tracking event",
+			input: CreativeRequest{ID: "preroll", Format: "video", Video: &VideoRequest{Duration: "15s",
+				MediaFiles: []MediaFileRequest{{URL: "https://cdn.example.com/preroll.mp4", Type: "video/mp4",
+					Width: 1280, Height: 720}},
+				TrackingEvents: map[string][]string{"skip": {"https://tracker.example.com/skip"}}}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `invalid video: unknown tracking event \"skip\"`,
+		},
+		{
+			name: "banner with a video",
+			input: CreativeRequest{ID: "banner", Width: 300, Height: 250, HTML: "<div>ad</div>",
+				Video: &VideoRequest{Duration: "15s"}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid creative: banner creatives have no video",
+		},
+		{
+			name: "native added",
+			input: CreativeRequest{ID: "infeed", Format: "native", Native: &NativeRequest{Title: "Summer sale",
+				Description: "Up to 50% off", Icon: &NativeImageRequest{URL: "https://cdn.example.com/logo.png", Width: 80, Height: 80},
+				Image: &NativeImageRequest{URL: "https://cdn.example.com/sale.png", Width: 1200, Height: 627},
+				CTA:   "Shop now", SponsoredBy: "Example"}, ClickURL: "https://example.com/sale"},
+			callAdd: true,
+			wantCreative: model.Creative{ID: "infeed", CampaignID: "camp123", Format: model.Native,
+				Native: model.NativeAd{Title: "Summer sale", Description: "Up to 50% off",
+					Icon:  model.NativeImage{URL: "https://cdn.example.com/logo.png", Size: model.Size{Width: 80, Height: 80}},
+					Image: model.NativeImage{URL: "https://cdn.example.com/sale.png", Size: model.Size{Width: 1200, Height: 627}},
+					CTA:   "Shop now", SponsoredBy: "Example"},
+				ClickURL: "https://example.com/sale"},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "native without assets",
+			input:        CreativeRequest{ID: "infeed", Format: "native"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "missing native",
+		},
+		{
+			name: "native with an html snippet",
+			input: CreativeRequest{ID: "infeed", Format: "native", HTML: "<div>ad</div>",
+				Native: &NativeRequest{Title: "Summer sale", SponsoredBy: "Example"}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid creative: native creatives only have native assets",
+		},
+		{
+			name: "native without sponsor",
+			input: CreativeRequest{ID: "infeed", Format: "native", Native: &NativeRequest{Title: "Summer sale",
+				Image: &NativeImageRequest{URL: "https://cdn.example.com/sale.png", Width: 1200, Height: 627}}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid native: missing sponsored_by",
+		},
+		{
+			name: "native call to action too long",
+			input: CreativeRequest{ID: "infeed", Format: "native", Native: &NativeRequest{Title: "Summer sale",
+				Image: &NativeImageRequest{URL: "https://cdn.example.com/sale.png", Width: 1200, Height: 627},
+				CTA:   "Discover the whole collection", SponsoredBy: "Example"}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid native: cta must be at most 15 characters",
+		},
+		{
+			name: "native without image",
+			input: CreativeRequest{ID: "infeed", Format: "native", Native: &NativeRequest{Title: "Summer sale",
+				SponsoredBy: "Example"}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid native: missing image",
+		},
+		{
+			name: "native icon without size",
+			input: CreativeRequest{ID: "infeed", Format: "native", Native: &NativeRequest{Title: "Summer sale",
+				Icon:        &NativeImageRequest{URL: "https://cdn.example.com/logo.png"},
+				Image:       &NativeImageRequest{URL: "https://cdn.example.com/sale.png", Width: 1200, Height: 627},
+				SponsoredBy: "Example"}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid native: icon: invalid size 0x0",
+		},
+		{
+			name:         "missing creative ID",
+			input:        CreativeRequest{Width: 300, Height: 250, HTML: "<div>ad</div>"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "missing creative ID",
+		},
+		{
+			name:         "invalid size",
+			input:        CreativeRequest{ID: "banner", Width: 300, HTML: "<div>ad</div>"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid size: 300x0",
+		},
+		{
+			name:         "missing image url and html",
+			input:        CreativeRequest{ID: "banner", Width: 300, Height: 250},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "missing image_url or html",
+		},
+		{
+			name: "both image url and html",
+			input: CreativeRequest{ID: "banner", Width: 300, Height: 250,
+				ImageURL: "https://cdn.example.com/banner.png", HTML: "<div>ad</div>"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid creative: image_url and html are exclusive",
+		},
+		{
+			name:         "relative image url",
+			input:        CreativeRequest{ID: "banner", Format: "banner", Width: 300, Height: 250, ImageURL: "/banner.png"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid image_url: /banner.png",
+		},
+		{
+			name: "invalid click url",
+			input: CreativeRequest{ID: "banner", Width: 300, Height: 250, HTML: "<div>ad</div>",
+				ClickURL: "javascript:alert(1)"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid click_url: javascript:alert(1)",
+		},
+		{
+			name:    "unknown campaign",
+			input:   CreativeRequest{ID: "snippet", Width: 728, Height: 90, HTML: "<div>ad</div>"},
+			callAdd: true,
+			wantCreative: model.Creative{ID: "snippet", CampaignID: "camp123", Format: model.Banner, Size: model.Size{Width: 728, Height: 90},
+				HTML: "<div>ad</div>"},
+			addErr:       pkg.Errorf(pkg.ENOTFOUND, "campaign with ID camp123 not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: "campaign with ID camp123 not found",
+		},
+		{
+			name:    "duplicate creative",
+			input:   CreativeRequest{ID: "snippet", Width: 728, Height: 90, HTML: "<div>ad</div>"},
+			callAdd: true,
+			wantCreative: model.Creative{ID: "snippet", CampaignID: "camp123", Format: model.Banner, Size: model.Size{Width: 728, Height: 90},
+				HTML: "<div>ad</div>"},
+			addErr:       pkg.Errorf(pkg.ECONFLICT, "creative with ID snippet already exists"),
+			expectedCode: http.StatusConflict,
+			expectedBody: "creative with ID snippet already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				AddCreativeFunc: func(ctx context.Context, creative model.Creative) error {
+					assert.Equal(t, tt.wantCreative, creative)
+					return tt.addErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock}
+
+			body, _ := json.Marshal(tt.input)
+			req := httptest.NewRequest(http.MethodPost, "/campaigns/camp123/creatives", bytes.NewBuffer(body))
+			req.SetPathValue("id", "camp123")
+			rec := httptest.NewRecorder()
+
+			handler.addCreative(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.callAdd, len(campaignServiceMock.AddCreativeCalls()) == 1)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCampaignsHandler_ListCreatives(t *testing.T) {
+	reviewedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		mockCreatives []model.Creative
+		listErr       error
+		expectedCode  int
+		expectedBody  *CreativesResponse
+	}{
+		{
+			name: "creatives in creation order",
+			mockCreatives: []model.Creative{
+				{ID: "banner", CampaignID: "camp123", Format: model.Banner, Size: model.Size{Width: 300, Height: 250},
+					ImageURL: "https://cdn.example.com/banner.png", ClickURL: "https://example.com/landing",
+					Review: model.Review{State: model.Approved, ReviewedAt: reviewedAt}},
+				{ID: "snippet", CampaignID: "camp123", Format: model.Banner, Size: model.Size{Width: 728, Height: 90},
+					HTML: "<div>ad</div>", Review: model.Review{State: model.Rejected, Reason: "misleading claim",
+						ReviewedAt: reviewedAt}},
+				{ID: "preroll", CampaignID: "camp123", Format: model.Video, Video: model.LinearVideo{
+					Duration: 15 * time.Second, MediaFiles: []model.MediaFile{{URL: "https://cdn.example.com/preroll.mp4",
+						MIMEType: "video/mp4", Width: 1280, Height: 720}}}, Review: model.Review{State: model.Pending}},
+				{ID: "infeed", CampaignID: "camp123", Format: model.Native, Native: model.NativeAd{Title: "Summer sale",
+					Image:       model.NativeImage{URL: "https://cdn.example.com/sale.png", Size: model.Size{Width: 1200, Height: 627}},
+					SponsoredBy: "Example"}, Review: model.Review{State: model.Pending}},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &CreativesResponse{Creatives: []CreativeResponse{
+				{ID: "banner", Format: "banner", Width: 300, Height: 250, ImageURL: "https://cdn.example.com/banner.png",
+					ClickURL: "https://example.com/landing", Review: &ReviewResponse{State: "approved", ReviewedAt: &reviewedAt}},
+				{ID: "snippet", Format: "banner", Width: 728, Height: 90, HTML: "<div>ad</div>",
+					Review: &ReviewResponse{State: "rejected", Reason: "misleading claim", ReviewedAt: &reviewedAt}},
+				{ID: "preroll", Format: "video", Video: &VideoRequest{Duration: "15s",
+					MediaFiles: []MediaFileRequest{{URL: "https://cdn.example.com/preroll.mp4", Type: "video/mp4",
+						Width: 1280, Height: 720}}}, Review: &ReviewResponse{State: "pending"}},
+				{ID: "infeed", Format: "native", Native: &NativeRequest{Title: "Summer sale",
+					Image:       &NativeImageRequest{URL: "https://cdn.example.com/sale.png", Width: 1200, Height: 627},
+					SponsoredBy: "Example"}, Review: &ReviewResponse{State: "pending"}},
+			}},
+		},
+		{
+			name:         "campaign without creative",
+			expectedCode: http.StatusOK,
+			expectedBody: &CreativesResponse{Creatives: []CreativeResponse{}},
+		},
+		{
+			name:         "unknown campaign",
+			listErr:      pkg.Errorf(pkg.ENOTFOUND, "campaign with ID camp123 not found"),
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				ListCreativesFunc: func(ctx context.Context, campaignID string) ([]model.Creative, error) {
+					assert.Equal(t, "camp123", campaignID)
+					return tt.mockCreatives, tt.listErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock}
+
+			req := httptest.NewRequest(http.MethodGet, "/campaigns/camp123/creatives", nil)
+			req.SetPathValue("id", "camp123")
+			rec := httptest.NewRecorder()
+
+			handler.listCreatives(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedBody != nil {
+				var got CreativesResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(t, *tt.expectedBody, got)
+			}
+		})
+	}
+}
+
+func TestCampaignsHandler_RemoveCreative(t *testing.T) {
+	tests := []struct {
+		name         string
+		removeErr    error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "creative removed",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "unknown creative",
+			removeErr:    pkg.Errorf(pkg.ENOTFOUND, "creative with ID banner not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: "creative with ID banner not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				RemoveCreativeFunc: func(ctx context.Context, campaignID, creativeID string) error {
+					assert.Equal(t, "camp123", campaignID)
+					assert.Equal(t, "banner", creativeID)
+					return tt.removeErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock}
+
+			req := httptest.NewRequest(http.MethodDelete, "/campaigns/camp123/creatives/banner", nil)
+			req.SetPathValue("id", "camp123")
+			req.SetPathValue("creative_id", "banner")
+			rec := httptest.NewRecorder()
+
+			handler.removeCreative(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Len(t, campaignServiceMock.RemoveCreativeCalls(), 1)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCampaignsHandler_ReviewCreative(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		body         string
+		reviewErr    error
+		callReview   bool
+		wantReview   model.Review
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "creative approved",
+			path:         "approve",
+			callReview:   true,
+			wantReview:   model.Review{State: model.Approved},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "creative rejected with its reason",
+			path:         "reject",
+			body:         `{"reason": "misleading claim"}`,
+			callReview:   true,
+			wantReview:   model.Review{State: model.Rejected, Reason: "misleading claim"},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "rejection without reason",
+			path:         "reject",
+			body:         `{"reason": " "}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "missing reason",
+		},
+		{
+			name:         "invalid rejection payload",
+			path:         "reject",
+			body:         `{"reason": 1}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid request payload",
+		},
+		{
+			name:         "unknown creative",
+			path:         "approve",
+			reviewErr:    pkg.Errorf(pkg.ENOTFOUND, "creative with ID banner not found"),
+			callReview:   true,
+			wantReview:   model.Review{State: model.Approved},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "creative with ID banner not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				ReviewCreativeFunc: func(ctx context.Context, campaignID, creativeID string, review model.Review) error {
+					assert.Equal(t, "camp123", campaignID)
+					assert.Equal(t, "banner", creativeID)
+					assert.Equal(t, tt.wantReview, review)
+					return tt.reviewErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock}
+
+			req := httptest.NewRequest(http.MethodPost, "/campaigns/camp123/creatives/banner/"+tt.path,
+				strings.NewReader(tt.body))
+			req.SetPathValue("id", "camp123")
+			req.SetPathValue("creative_id", "banner")
+			rec := httptest.NewRecorder()
+
+			if tt.path == "approve" {
+				handler.approveCreative(rec, req)
+			} else {
+				handler.rejectCreative(rec, req)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.callReview, len(campaignServiceMock.ReviewCreativeCalls()) == 1)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
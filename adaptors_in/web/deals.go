@@ -0,0 +1,70 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"github.com/shopspring/decimal"
+)
+
+type DealRequest struct {
+	ID string `json:"id"`
+	// Price is the fixed price per delivery the campaigns of the deal pay.
+	Price     decimal.Decimal `json:"price"`
+	Campaigns []string        `json:"campaigns"`
+	// Publishers allowed to sell their inventory through the deal, any publisher when empty.
+	Publishers []string `json:"publishers"`
+}
+
+// @Summary      Set a private marketplace deal
+// @Description  Sets the deal with the given ID, replacing the existing deal. Deliveries requested with
+// @Description  the deal ID are reserved to its campaigns, which pay its fixed price per delivery.
+// @Description  Omitted publishers allow any publisher to sell through the deal.
+// @Tags         deals
+// @Accept       json
+// @Param        request  body  DealRequest  true  "Deal request"
+// @Success      204      "Deal set (no content)"
+// @Failure      400      {object}  pkg.ErrorResp
+// @Failure      500      {object}  pkg.ErrorResp
+// @Router       /deals [put]
+func (h *CampaignsHandler) setDeal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input := DealRequest{}
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid request payload: %v", err))
+		return
+	}
+
+	if len(input.ID) == 0 {
+		pkg.BadRequestResponse(w, r, "missing deal ID")
+		return
+	}
+
+	if !input.Price.IsPositive() {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid price: %v", input.Price))
+		return
+	}
+
+	if len(input.Campaigns) == 0 {
+		pkg.BadRequestResponse(w, r, "missing deal campaigns")
+		return
+	}
+
+	err = h.UseCase.SetDeal(ctx, model.Deal{
+		ID:         input.ID,
+		Price:      input.Price,
+		Campaigns:  input.Campaigns,
+		Publishers: input.Publishers,
+	})
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
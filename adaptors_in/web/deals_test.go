@@ -0,0 +1,156 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/ports_in"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignsHandler_SetDeal(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        DealRequest
+		wantDeal     model.Deal
+		setErr       error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "successful deal for publishers",
+			input: DealRequest{
+				ID:         "deal1",
+				Price:      decimal.NewFromFloat(2.5),
+				Campaigns:  []string{"1", "2"},
+				Publishers: []string{"pub1"},
+			},
+			wantDeal: model.Deal{
+				ID:         "deal1",
+				Price:      decimal.NewFromFloat(2.5),
+				Campaigns:  []string{"1", "2"},
+				Publishers: []string{"pub1"},
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "successful deal for any publisher",
+			input: DealRequest{
+				ID:        "deal1",
+				Price:     decimal.NewFromFloat(2.5),
+				Campaigns: []string{"1"},
+			},
+			wantDeal: model.Deal{
+				ID:        "deal1",
+				Price:     decimal.NewFromFloat(2.5),
+				Campaigns: []string{"1"},
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "missing deal ID",
+			input: DealRequest{
+				Price:     decimal.NewFromFloat(2.5),
+				Campaigns: []string{"1"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "missing deal ID",
+		},
+		{
+			name: "invalid price",
+			input: DealRequest{
+				ID:        "deal1",
+				Campaigns: []string{"1"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid price: 0",
+		},
+		{
+			name: "missing campaigns",
+			input: DealRequest{
+				ID:    "deal1",
+				Price: decimal.NewFromFloat(2.5),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "missing deal campaigns",
+		},
+		{
+			name: "error from SetDeal method in domain",
+			input: DealRequest{
+				ID:        "deal1",
+				Price:     decimal.NewFromFloat(2.5),
+				Campaigns: []string{"1"},
+			},
+			wantDeal: model.Deal{
+				ID:        "deal1",
+				Price:     decimal.NewFromFloat(2.5),
+				Campaigns: []string{"1"},
+			},
+			setErr:       pkg.Errorf(pkg.EINTERNAL, "internal error"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				SetDealFunc: func(ctx context.Context, deal model.Deal) error {
+					assert.Equal(t, tt.wantDeal.ID, deal.ID)
+					assert.True(t, tt.wantDeal.Price.Equal(deal.Price))
+					assert.Equal(t, tt.wantDeal.Campaigns, deal.Campaigns)
+					assert.Equal(t, tt.wantDeal.Publishers, deal.Publishers)
+					return tt.setErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock}
+
+			body, _ := json.Marshal(tt.input)
+			req := httptest.NewRequest(http.MethodPut, "/deals", bytes.NewBuffer(body))
+			rec := httptest.NewRecorder()
+
+			handler.setDeal(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCampaignsHandler_MatchWithoutDeal(t *testing.T) {
+	validConsentString := "CQMGLkAQMGLkABcAKEFRBbFgAP_gAEPgAAqIJnkR_C9MQWFjcT51AfskaYxHxgACo" +
+		"EQgBACJgygBCAPA8IQEwGAYIAxAAqAKAAAAoiRBAAAlCAhQAAAAQAAAACCMAEAAAAAAIKBAgAARAgEACAhB" +
+		"GQAAEAAAAIBBABAAgAAEQBoAQBAAAAAAAAAgAAAgAACBAAAIAAAAAAEAAAAIAEgAAAAAAAAAAAAAAlAIAAA" +
+		"IAAAAAAAAAAAIJngAmChEQAFgQAhAAGEECABQRgAAAAAgAACBggAACAAA4AQAUGAAAAAAAAAIAAAAggABAAA" +
+		"BAAhAAAAAQAAAAAAIAAAAAAAAACBAAAABAAAAAAgAAQAAAAAAAABAABAAgAAAABAAQBAAAAAgAAAAAAAAAAC" +
+		"AAAAAAAAAAAEAAAAIAEAAAAAAAAAAAAAAAAAIAAAAAAAAAAAAAAAAAAA"
+
+	campaignServiceMock := &ports_in.CampaignServiceMock{
+		MatchFunc: func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
+			assert.Equal(t, []string{"expired"}, req.DealIDs)
+			assert.True(t, req.PrivateAuction)
+			return model.MatchResult{NoMatchReason: model.NoDeal}, nil
+		},
+	}
+	handler := CampaignsHandler{UseCase: campaignServiceMock}
+
+	body, _ := json.Marshal(CampaignMatchRequest{Country: "FR", Device: "mobile", OS: "android",
+		PublisherID: "pub1", DealIDs: []string{"expired"}, PrivateAuction: true})
+	req := httptest.NewRequest(http.MethodPost, "/deliver", bytes.NewBuffer(body))
+	req.Header.Set("X-Consent-String", validConsentString)
+	rec := httptest.NewRecorder()
+
+	handler.match(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "no_deal", rec.Header().Get("X-No-Match-Reason"))
+}
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"github.com/shopspring/decimal"
+)
+
+type CandidateExplanationResponse struct {
+	Rank         int             `json:"rank"`
+	CampaignID   string          `json:"campaign_id"`
+	Bid          decimal.Decimal `json:"bid"`
+	EffectiveBid decimal.Decimal `json:"effective_bid"`
+	ECPM         decimal.Decimal `json:"ecpm"`
+	PricingModel string          `json:"pricing_model"`
+	Priority     string          `json:"priority"`
+	Verdict      string          `json:"verdict"`
+	// ClearingPrice and DealID are only set for the winners.
+	ClearingPrice *decimal.Decimal `json:"clearing_price,omitempty"`
+	DealID        string           `json:"deal_id,omitempty"`
+}
+
+type ExplainResponse struct {
+	Floor         decimal.Decimal                `json:"floor"`
+	NoMatchReason string                         `json:"no_match_reason,omitempty"`
+	Candidates    []CandidateExplanationResponse `json:"candidates"`
+}
+
+// @Summary      Explain a delivery
+// @Description  Runs the delivery of the same request as /deliver without deducting any budget nor recording
+// @Description  the deliveries of the user and the page view, and returns every candidate in ranking order with
+// @Description  its verdict: won, outranked, inactive, expired, out_of_budget, goal_reached, below_floor,
+// @Description  frequency_capped, recency_capped, out_of_sequence, paced, competing_category, no_creative,
+// @Description  duplicate_advertiser or not_in_deal.
+// @Tags         campaigns
+// @Accept       json
+// @Produce      json
+// @Param        X-Consent-String  header  string                true  "Consent string"
+// @Param        request           body    CampaignMatchRequest  true  "Campaign match request"
+// @Success      200               {object} ExplainResponse
+// @Failure      400               {object} pkg.ErrorResp
+// @Failure      404               {object} pkg.ErrorResp
+// @Failure      500               {object} pkg.ErrorResp
+// @Router       /deliver/explain [post]
+func (h *CampaignsHandler) explain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !checkConsent(w, r) {
+		return
+	}
+
+	input := CampaignMatchRequest{}
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid request payload: %v", err))
+		return
+	}
+
+	req, err := parseMatchRequest(input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, err.Error())
+		return
+	}
+
+	explanation, err := h.UseCase.Explain(ctx, req)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	candidates := make([]CandidateExplanationResponse, 0, len(explanation.Candidates))
+	for _, c := range explanation.Candidates {
+		candidate := CandidateExplanationResponse{
+			Rank:         c.Rank,
+			CampaignID:   c.ID,
+			Bid:          c.Bid,
+			EffectiveBid: c.EffectiveBid,
+			ECPM:         c.ECPM,
+			PricingModel: string(c.PricingModel),
+			Priority:     string(c.Priority),
+			Verdict:      string(c.Verdict),
+			DealID:       c.DealID,
+		}
+		if c.Verdict == model.Won {
+			candidate.ClearingPrice = &c.ClearingPrice
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	pkg.JsonResponse(w, r, http.StatusOK, ExplainResponse{
+		Floor:         explanation.Floor,
+		NoMatchReason: string(explanation.NoMatchReason),
+		Candidates:    candidates,
+	})
+}
@@ -0,0 +1,169 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/ports_in"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignsHandler_Explain(t *testing.T) {
+	// String for TCF v2 format with valid consents
+	validConsentString := "CQMGLkAQMGLkABcAKEFRBbFgAP_gAEPgAAqIJnkR_C9MQWFjcT51AfskaYxHxgACo" +
+		"EQgBACJgygBCAPA8IQEwGAYIAxAAqAKAAAAoiRBAAAlCAhQAAAAQAAAACCMAEAAAAAAIKBAgAARAgEACAhB" +
+		"GQAAEAAAAIBBABAAgAAEQBoAQBAAAAAAAAAgAAAgAACBAAAIAAAAAAEAAAAIAEgAAAAAAAAAAAAAAlAIAAA" +
+		"IAAAAAAAAAAAIJngAmChEQAFgQAhAAGEECABQRgAAAAAgAACBggAACAAA4AQAUGAAAAAAAAAIAAAAggABAAA" +
+		"BAAhAAAAAQAAAAAAIAAAAAAAAACBAAAABAAAAAAgAAQAAAAAAAABAABAAgAAAABAAQBAAAAAgAAAAAAAAAAC" +
+		"AAAAAAAAAAAEAAAAIAEAAAAAAAAAAAAAAAAAIAAAAAAAAAAAAAAAAAAA"
+
+	successfulExplanation := `{
+	"floor": "1",
+	"candidates": [
+		{
+			"rank": 1,
+			"campaign_id": "camp123",
+			"bid": "2",
+			"effective_bid": "2",
+			"ecpm": "2000",
+			"pricing_model": "cpd",
+			"priority": "standard",
+			"verdict": "won",
+			"clearing_price": "1.5"
+		},
+		{
+			"rank": 2,
+			"campaign_id": "camp456",
+			"bid": "1.5",
+			"effective_bid": "1.5",
+			"ecpm": "1500",
+			"pricing_model": "cpd",
+			"priority": "standard",
+			"verdict": "outranked"
+		}
+	]
+}
+`
+	tests := []struct {
+		name             string
+		consentToken     string
+		input            CampaignMatchRequest
+		callExplain      bool
+		mockExplanation  model.Explanation
+		mockExplainError error
+		expectedCode     int
+		expectedBody     string
+	}{
+		{
+			name:         "successful explanation",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country: "FR",
+				Device:  "mobile",
+				OS:      "android",
+				UserID:  "user1",
+			},
+			callExplain: true,
+			mockExplanation: model.Explanation{
+				Floor: decimal.NewFromFloat(1),
+				Candidates: []model.CandidateExplanation{
+					{
+						CampaignMatch: model.CampaignMatch{ID: "camp123", Bid: decimal.NewFromFloat(2),
+							EffectiveBid: decimal.NewFromFloat(2), PricingModel: model.CPD, Priority: model.Standard,
+							ClearingPrice: decimal.NewFromFloat(1.5)},
+						ECPM: decimal.NewFromFloat(2000), Rank: 1, Verdict: model.Won,
+					},
+					{
+						CampaignMatch: model.CampaignMatch{ID: "camp456", Bid: decimal.NewFromFloat(1.5),
+							EffectiveBid: decimal.NewFromFloat(1.5), PricingModel: model.CPD, Priority: model.Standard},
+						ECPM: decimal.NewFromFloat(1500), Rank: 2, Verdict: model.Outranked,
+					},
+				},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: successfulExplanation,
+		},
+		{
+			name:         "successful explanation without match",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country: "FR",
+				Device:  "mobile",
+				OS:      "android",
+			},
+			callExplain:     true,
+			mockExplanation: model.Explanation{NoMatchReason: model.BelowFloor},
+			expectedCode:    http.StatusOK,
+			expectedBody:    `"no_match_reason": "below_floor"`,
+		},
+		{
+			name: "missing consent string",
+			input: CampaignMatchRequest{
+				Country: "FR",
+				Device:  "mobile",
+				OS:      "android",
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "missing header X-Consent-String",
+		},
+		{
+			name:         "invalid country",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country: "invalid_country",
+				Device:  "mobile",
+				OS:      "android",
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid country: invalid_country",
+		},
+		{
+			name:         "no campaign found",
+			consentToken: validConsentString,
+			input: CampaignMatchRequest{
+				Country: "FR",
+				Device:  "mobile",
+				OS:      "android",
+			},
+			callExplain:      true,
+			mockExplainError: pkg.Errorf(pkg.ENOTFOUND, "no campaign found for FR, mobile, android"),
+			expectedCode:     http.StatusNotFound,
+			expectedBody:     "no campaign found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				ExplainFunc: func(ctx context.Context, req model.MatchRequest) (model.Explanation, error) {
+					assert.Equal(t, model.Countries[tt.input.Country], req.Country)
+					assert.Equal(t, tt.input.UserID, req.UserID)
+					return tt.mockExplanation, tt.mockExplainError
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock}
+
+			body, _ := json.Marshal(tt.input)
+			req := httptest.NewRequest(http.MethodPost, "/deliver/explain", bytes.NewBuffer(body))
+			if tt.consentToken != "" {
+				req.Header.Set("X-Consent-String", tt.consentToken)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.explain(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.callExplain, len(campaignServiceMock.ExplainCalls()) == 1)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
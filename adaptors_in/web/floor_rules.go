@@ -0,0 +1,76 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"github.com/shopspring/decimal"
+)
+
+type FloorRuleRequest struct {
+	Country string          `json:"country"`
+	Device  string          `json:"device"`
+	OS      string          `json:"os"`
+	Floor   decimal.Decimal `json:"floor"`
+}
+
+// @Summary      Set a bid floor rule
+// @Description  Sets the minimum bid accepted for a country, device and OS, replacing the existing rule.
+// @Description  Omitted targeting fields match any value; the most specific rule applies at delivery.
+// @Tags         floor-rules
+// @Accept       json
+// @Param        request  body  FloorRuleRequest  true  "Floor rule request"
+// @Success      204      "Floor rule set (no content)"
+// @Failure      400      {object}  pkg.ErrorResp
+// @Failure      500      {object}  pkg.ErrorResp
+// @Router       /floor-rules [put]
+func (h *CampaignsHandler) setFloorRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input := FloorRuleRequest{}
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid request payload: %v", err))
+		return
+	}
+
+	rule := model.FloorRule{Floor: input.Floor}
+	var ok bool
+
+	if input.Country != "" {
+		if rule.Country, ok = model.Countries[input.Country]; !ok {
+			pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid country: %v", input.Country))
+			return
+		}
+	}
+
+	if input.Device != "" {
+		if rule.Device, ok = model.Devices[input.Device]; !ok {
+			pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid device: %v", input.Device))
+			return
+		}
+	}
+
+	if input.OS != "" {
+		if rule.OS, ok = model.OperationalSystems[input.OS]; !ok {
+			pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid os: %v", input.OS))
+			return
+		}
+	}
+
+	if input.Floor.IsNegative() {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid floor: %v", input.Floor))
+		return
+	}
+
+	err = h.UseCase.SetFloorRule(ctx, rule)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,119 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/ports_in"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignsHandler_SetFloorRule(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        FloorRuleRequest
+		wantRule     model.FloorRule
+		setErr       error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "successful rule for country and os",
+			input: FloorRuleRequest{
+				Country: "FR",
+				OS:      "android",
+				Floor:   decimal.NewFromFloat(1.5),
+			},
+			wantRule: model.FloorRule{
+				Targeting: model.Targeting{Country: model.France, OS: model.Android},
+				Floor:     decimal.NewFromFloat(1.5),
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "successful catch-all rule",
+			input: FloorRuleRequest{
+				Floor: decimal.NewFromFloat(0.1),
+			},
+			wantRule:     model.FloorRule{Floor: decimal.NewFromFloat(0.1)},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "invalid country",
+			input: FloorRuleRequest{
+				Country: "invalid_country",
+				Floor:   decimal.NewFromFloat(1.5),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid country: invalid_country",
+		},
+		{
+			name: "invalid device",
+			input: FloorRuleRequest{
+				Device: "invalid_device",
+				Floor:  decimal.NewFromFloat(1.5),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid device: invalid_device",
+		},
+		{
+			name: "invalid os",
+			input: FloorRuleRequest{
+				OS:    "invalid_os",
+				Floor: decimal.NewFromFloat(1.5),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid os: invalid_os",
+		},
+		{
+			name: "invalid floor",
+			input: FloorRuleRequest{
+				Country: "FR",
+				Floor:   decimal.NewFromFloat(-1.5),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid floor: -1.5",
+		},
+		{
+			name: "error from SetFloorRule method in domain",
+			input: FloorRuleRequest{
+				Floor: decimal.NewFromFloat(1),
+			},
+			wantRule:     model.FloorRule{Floor: decimal.NewFromFloat(1)},
+			setErr:       pkg.Errorf(pkg.EINTERNAL, "internal error"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				SetFloorRuleFunc: func(ctx context.Context, rule model.FloorRule) error {
+					assert.Equal(t, tt.wantRule.Targeting, rule.Targeting)
+					assert.True(t, tt.wantRule.Floor.Equal(rule.Floor))
+					return tt.setErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock}
+
+			body, _ := json.Marshal(tt.input)
+			req := httptest.NewRequest(http.MethodPut, "/floor-rules", bytes.NewBuffer(body))
+			rec := httptest.NewRecorder()
+
+			handler.setFloorRule(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
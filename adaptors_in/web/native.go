@@ -0,0 +1,164 @@
+package web
+
+import (
+	"encoding/json"
+	"unicode/utf8"
+
+	"ad-campaign-delivery/model"
+)
+
+// OpenRTBNative is the native slot of the impression, whose request is a Native 1.2 request
+// encoded in a JSON string.
+type OpenRTBNative struct {
+	Request string `json:"request"`
+	Ver     string `json:"ver,omitempty"`
+}
+
+// OpenRTBNativeRequest is the subset of the Native 1.2 request listing the assets of the slot.
+type OpenRTBNativeRequest struct {
+	Ver    string                      `json:"ver,omitempty"`
+	Assets []OpenRTBNativeRequestAsset `json:"assets"`
+}
+
+// OpenRTBNativeRequestAsset is an asset of the native slot: a title of at most len characters,
+// an image of the given type, or data of the given type and at most len characters.
+type OpenRTBNativeRequestAsset struct {
+	ID       int                       `json:"id"`
+	Required int                       `json:"required,omitempty"`
+	Title    *OpenRTBNativeTitle       `json:"title,omitempty"`
+	Img      *OpenRTBNativeImage       `json:"img,omitempty"`
+	Data     *OpenRTBNativeDataRequest `json:"data,omitempty"`
+}
+
+type OpenRTBNativeDataRequest struct {
+	Type int `json:"type"`
+	Len  int `json:"len,omitempty"`
+}
+
+// OpenRTBNativeResponse is the Native 1.2 response of a native creative, filling the assets of the
+// request it has. Its link records the click and its event tracker confirms the delivery.
+type OpenRTBNativeResponse struct {
+	Ver           string                      `json:"ver"`
+	Assets        []OpenRTBNativeAsset        `json:"assets"`
+	Link          OpenRTBNativeLink           `json:"link"`
+	EventTrackers []OpenRTBNativeEventTracker `json:"eventtrackers"`
+}
+
+type OpenRTBNativeAsset struct {
+	ID    int                 `json:"id"`
+	Title *OpenRTBNativeTitle `json:"title,omitempty"`
+	Img   *OpenRTBNativeImage `json:"img,omitempty"`
+	Data  *OpenRTBNativeData  `json:"data,omitempty"`
+}
+
+type OpenRTBNativeTitle struct {
+	Text string `json:"text,omitempty"`
+	Len  int    `json:"len,omitempty"`
+}
+
+type OpenRTBNativeImage struct {
+	Type int    `json:"type,omitempty"`
+	URL  string `json:"url,omitempty"`
+	W    int    `json:"w,omitempty"`
+	H    int    `json:"h,omitempty"`
+}
+
+type OpenRTBNativeData struct {
+	Type  int    `json:"type,omitempty"`
+	Value string `json:"value"`
+}
+
+type OpenRTBNativeLink struct {
+	URL string `json:"url"`
+}
+
+type OpenRTBNativeEventTracker struct {
+	Event  int    `json:"event"`
+	Method int    `json:"method"`
+	URL    string `json:"url"`
+}
+
+const (
+	// nativeMediaType is the OpenRTB 2.6 media type of the native bids.
+	nativeMediaType = 4
+
+	nativeVersion = "1.2"
+
+	// the Native 1.2 image asset types
+	nativeImageIcon = 1
+	nativeImageMain = 3
+
+	// the Native 1.2 data asset types
+	nativeDataSponsored = 1
+	nativeDataDesc      = 2
+	nativeDataCTA       = 12
+
+	// the Native 1.2 impression event, tracked by an image pixel
+	nativeEventImpression = 1
+	nativeMethodImage     = 1
+)
+
+// defaultNativeRequest asks for every asset of the native creatives, for the deliveries without
+// a Native 1.2 request.
+var defaultNativeRequest = OpenRTBNativeRequest{Ver: nativeVersion, Assets: []OpenRTBNativeRequestAsset{
+	{ID: 1, Title: &OpenRTBNativeTitle{}},
+	{ID: 2, Img: &OpenRTBNativeImage{Type: nativeImageIcon}},
+	{ID: 3, Img: &OpenRTBNativeImage{Type: nativeImageMain}},
+	{ID: 4, Data: &OpenRTBNativeDataRequest{Type: nativeDataDesc}},
+	{ID: 5, Data: &OpenRTBNativeDataRequest{Type: nativeDataCTA}},
+	{ID: 6, Data: &OpenRTBNativeDataRequest{Type: nativeDataSponsored}},
+}}
+
+// nativeRequest decodes the Native 1.2 request of the slot, which may still be wrapped in a native
+// object as before Native 1.1.
+func (n *OpenRTBNative) nativeRequest() (OpenRTBNativeRequest, error) {
+	var request struct {
+		OpenRTBNativeRequest
+		Native *OpenRTBNativeRequest `json:"native"`
+	}
+	if err := json.Unmarshal([]byte(n.Request), &request); err != nil {
+		return OpenRTBNativeRequest{}, err
+	}
+	if request.Native != nil {
+		return *request.Native, nil
+	}
+	return request.OpenRTBNativeRequest, nil
+}
+
+// nativeMarkup returns the Native 1.2 response of the creative, with the assets of the request it has
+// within their maximum length, linking to the click URL and tracking the impression URL.
+func nativeMarkup(creative model.Creative, request OpenRTBNativeRequest, clickURL, impressionURL string) OpenRTBNativeResponse {
+	native := creative.Native
+	response := OpenRTBNativeResponse{
+		Ver:  nativeVersion,
+		Link: OpenRTBNativeLink{URL: clickURL},
+		EventTrackers: []OpenRTBNativeEventTracker{
+			{Event: nativeEventImpression, Method: nativeMethodImage, URL: impressionURL},
+		},
+	}
+
+	images := map[int]model.NativeImage{nativeImageIcon: native.Icon, nativeImageMain: native.Image}
+	data := map[int]string{nativeDataSponsored: native.SponsoredBy, nativeDataDesc: native.Description,
+		nativeDataCTA: native.CTA}
+
+	for _, asset := range request.Assets {
+		switch {
+		case asset.Title != nil && fitsLen(native.Title, asset.Title.Len):
+			response.Assets = append(response.Assets, OpenRTBNativeAsset{ID: asset.ID,
+				Title: &OpenRTBNativeTitle{Text: native.Title}})
+		case asset.Img != nil && images[asset.Img.Type].URL != "":
+			image := images[asset.Img.Type]
+			response.Assets = append(response.Assets, OpenRTBNativeAsset{ID: asset.ID,
+				Img: &OpenRTBNativeImage{URL: image.URL, W: image.Size.Width, H: image.Size.Height}})
+		case asset.Data != nil && data[asset.Data.Type] != "" && fitsLen(data[asset.Data.Type], asset.Data.Len):
+			response.Assets = append(response.Assets, OpenRTBNativeAsset{ID: asset.ID,
+				Data: &OpenRTBNativeData{Value: data[asset.Data.Type]}})
+		}
+	}
+	return response
+}
+
+// fitsLen tells whether the text is at most max characters long, any length fitting when max is zero.
+func fitsLen(text string, max int) bool {
+	return max == 0 || utf8.RuneCountInString(text) <= max
+}
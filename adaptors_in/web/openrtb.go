@@ -0,0 +1,448 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"github.com/shopspring/decimal"
+)
+
+// OpenRTBBidRequest is the subset of the OpenRTB 2.6 bid request used to match its impressions.
+type OpenRTBBidRequest struct {
+	ID     string            `json:"id"`
+	Imp    []OpenRTBImp      `json:"imp"`
+	Site   *OpenRTBInventory `json:"site,omitempty"`
+	App    *OpenRTBInventory `json:"app,omitempty"`
+	Device OpenRTBDevice     `json:"device"`
+	User   OpenRTBUser       `json:"user"`
+	Regs   OpenRTBRegs       `json:"regs"`
+	// TMax is the time in milliseconds the exchange waits for the bids, unlimited when unset.
+	TMax int `json:"tmax,omitempty"`
+}
+
+// OpenRTBImp is an impression of the bid request. The bid floor is a price per thousand deliveries.
+type OpenRTBImp struct {
+	ID       string          `json:"id"`
+	BidFloor decimal.Decimal `json:"bidfloor"`
+	Banner   *OpenRTBBanner  `json:"banner,omitempty"`
+	Native   *OpenRTBNative  `json:"native,omitempty"`
+	PMP      *OpenRTBPMP     `json:"pmp,omitempty"`
+	Ext      OpenRTBImpExt   `json:"ext"`
+}
+
+// OpenRTBBanner is the banner slot of the impression, accepting the sizes of its formats,
+// or its w and h.
+type OpenRTBBanner struct {
+	W      int             `json:"w,omitempty"`
+	H      int             `json:"h,omitempty"`
+	Format []OpenRTBFormat `json:"format,omitempty"`
+}
+
+type OpenRTBFormat struct {
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// OpenRTBImpExt holds the params of the impression for this bidder, sent by Prebid Server.
+type OpenRTBImpExt struct {
+	Bidder OpenRTBBidderParams `json:"bidder"`
+}
+
+// OpenRTBBidderParams are the params of the bidder configured in Prebid.
+type OpenRTBBidderParams struct {
+	// PublisherID identifies the publisher of the deals, instead of the site or app publisher.
+	PublisherID string `json:"publisher_id"`
+}
+
+// OpenRTBPMP lists the private marketplace deals the impression is offered through.
+type OpenRTBPMP struct {
+	PrivateAuction int           `json:"private_auction"`
+	Deals          []OpenRTBDeal `json:"deals"`
+}
+
+type OpenRTBDeal struct {
+	ID string `json:"id"`
+}
+
+// OpenRTBInventory is the site or app of the impressions.
+type OpenRTBInventory struct {
+	ID        string            `json:"id"`
+	Publisher *OpenRTBPublisher `json:"publisher,omitempty"`
+}
+
+type OpenRTBPublisher struct {
+	ID string `json:"id"`
+}
+
+// OpenRTBDevice is the device of the user, whose type is one of the AdCOM device types.
+type OpenRTBDevice struct {
+	OS         string     `json:"os"`
+	DeviceType int        `json:"devicetype"`
+	Geo        OpenRTBGeo `json:"geo"`
+}
+
+// OpenRTBGeo is the location of the device, whose country is an ISO 3166-1 alpha-3 code.
+type OpenRTBGeo struct {
+	Country string `json:"country"`
+}
+
+// OpenRTBUser is the user of the device, whose consent string is read from user.consent,
+// or from user.ext.consent before OpenRTB 2.6.
+type OpenRTBUser struct {
+	ID       string         `json:"id"`
+	BuyerUID string         `json:"buyeruid"`
+	Consent  string         `json:"consent"`
+	Ext      OpenRTBUserExt `json:"ext"`
+}
+
+type OpenRTBUserExt struct {
+	Consent string `json:"consent"`
+}
+
+// OpenRTBRegs holds the regulations of the request, whose gdpr flag is read from regs.gdpr,
+// or from regs.ext.gdpr before OpenRTB 2.6.
+type OpenRTBRegs struct {
+	GDPR *int           `json:"gdpr,omitempty"`
+	Ext  OpenRTBRegsExt `json:"ext"`
+}
+
+type OpenRTBRegsExt struct {
+	GDPR *int `json:"gdpr,omitempty"`
+}
+
+// OpenRTBBidResponse is the OpenRTB 2.6 bid response, with a single seat bidding on the impressions.
+type OpenRTBBidResponse struct {
+	ID      string           `json:"id"`
+	SeatBid []OpenRTBSeatBid `json:"seatbid"`
+}
+
+type OpenRTBSeatBid struct {
+	Bid []OpenRTBBid `json:"bid"`
+}
+
+// OpenRTBBid is the bid on an impression, identified by the reservation of its delivery. The price
+// is the clearing price per thousand deliveries, and the win and loss notices are the nurl and lurl.
+// The markup, creative ID and size are those of the creative delivered. The markup of the native bids
+// is their Native 1.2 response encoded in a JSON string.
+type OpenRTBBid struct {
+	ID         string         `json:"id"`
+	ImpID      string         `json:"impid"`
+	Price      float64        `json:"price"`
+	NURL       string         `json:"nurl"`
+	LURL       string         `json:"lurl"`
+	AdM        string         `json:"adm,omitempty"`
+	CampaignID string         `json:"cid"`
+	CreativeID string         `json:"crid"`
+	W          int            `json:"w,omitempty"`
+	H          int            `json:"h,omitempty"`
+	DealID     string         `json:"dealid,omitempty"`
+	MediaType  int            `json:"mtype"`
+	Ext        *OpenRTBBidExt `json:"ext,omitempty"`
+}
+
+// OpenRTBBidExt holds the extension of the bids read by Prebid Server.
+type OpenRTBBidExt struct {
+	Prebid PrebidBidExt `json:"prebid"`
+}
+
+// PrebidBidExt holds the media type of the bid, as named by Prebid.
+type PrebidBidExt struct {
+	Type string `json:"type"`
+}
+
+// bannerMediaType is the OpenRTB 2.6 media type of the banner bids.
+const bannerMediaType = 1
+
+// openRTBDevices maps the AdCOM device types onto the devices, the other types only matching
+// the campaigns of any device.
+var openRTBDevices = map[int]model.Device{
+	1: model.Mobile, // mobile/tablet
+	2: model.Desktop,
+	4: model.Mobile, // phone
+	5: model.Tablet,
+}
+
+// openRTBOSAliases maps the lower case OpenRTB OS names differing from the names of the operational systems.
+var openRTBOSAliases = map[string]string{
+	"macos":    "mac",
+	"mac os x": "mac",
+	"os x":     "mac",
+}
+
+// @Summary      Bid on an OpenRTB request
+// @Description  Matches a campaign for each impression of an OpenRTB 2.6 bid request, like /deliver with a single slot.
+// @Description  The country of device.geo is an ISO-3 code, and device.os and device.devicetype are mapped onto the
+// @Description  targeting: unknown values only match the campaigns targeting any value. The bid floors of the
+// @Description  impressions and the prices of the bids are per thousand deliveries.
+// @Description  When regs.gdpr is 1, the consent string of user.consent, or user.ext.consent, must grant the consent
+// @Description  required by /deliver, otherwise no bid is made. The user buyeruid, or id, caps the deliveries to
+// @Description  the user, and the bid request id is the page view of the impressions.
+// @Description  Each bid reserves the cost of its delivery: its nurl confirms it at the clearing price of the
+// @Description  exchange, and its lurl releases it. The impressions left once tmax elapsed are not bid on, and
+// @Description  the impression being matched then reserves nothing.
+// @Description  The sizes of the banner formats, or its w and h, are the sizes of the slot: the bids are made
+// @Description  with the adm, crid, w and h of the creative fitting it. The impressions with a native slot are bid
+// @Description  with the native creatives as well, whose adm is their Native 1.2 response to the native request.
+// @Description  The impressions without banner nor native slot, like the video ones, are not bid on.
+// @Tags         openrtb
+// @Accept       json
+// @Produce      json
+// @Param        request  body      OpenRTBBidRequest   true  "OpenRTB 2.6 bid request"
+// @Success      200      {object}  OpenRTBBidResponse  "Bids on the impressions"
+// @Success      204      "No bid"
+// @Failure      400      {object}  pkg.ErrorResp
+// @Failure      500      {object}  pkg.ErrorResp
+// @Router       /openrtb2/bid [post]
+func (h *CampaignsHandler) bid(w http.ResponseWriter, r *http.Request) {
+	input, reqs, ok := decodeBidRequest(w, r)
+	if !ok {
+		return
+	}
+
+	bids, err := h.bidOnImpressions(r.Context(), input, reqs)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	writeBidResponse(w, r, input.ID, bids)
+}
+
+// decodeBidRequest decodes the bid request and the delivery requests of its impressions,
+// answering the error when it is invalid.
+func decodeBidRequest(w http.ResponseWriter, r *http.Request) (OpenRTBBidRequest, []model.MatchRequest, bool) {
+	input := OpenRTBBidRequest{}
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid request payload: %v", err))
+		return OpenRTBBidRequest{}, nil, false
+	}
+
+	reqs, err := parseBidRequest(input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, err.Error())
+		return OpenRTBBidRequest{}, nil, false
+	}
+	return input, reqs, true
+}
+
+// bidOnImpressions matches the delivery requests of the impressions in turn and returns the bids on
+// the matched ones, until the exchange stops waiting for them after tmax: the match running then
+// reserves nothing, and the impressions left are not bid on. Nothing is bid without the consent of
+// the user.
+func (h *CampaignsHandler) bidOnImpressions(ctx context.Context, input OpenRTBBidRequest, reqs []model.MatchRequest) ([]OpenRTBBid, error) {
+	if !input.consented() {
+		return nil, nil
+	}
+
+	if input.TMax > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(input.TMax)*time.Millisecond)
+		defer cancel()
+	}
+
+	var bids []OpenRTBBid
+	for i, req := range reqs {
+		// the bids of the impressions left would arrive too late
+		if ctx.Err() != nil {
+			break
+		}
+
+		// the video or audio impressions are not bid on, without slot for the banner and native creatives
+		if len(req.Formats) == 0 {
+			continue
+		}
+
+		result, err := h.UseCase.Match(ctx, req)
+		// tmax elapsed during the match, which reserved nothing, and the bids so far are answered
+		if ctx.Err() != nil {
+			break
+		}
+		// an impression without candidates is not bid on
+		if pkg.ErrorCode(err) == pkg.ENOTFOUND {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range result.Matches {
+			tracking := h.Signer.NewTracking(m.ReservationID, m.ID, m.Creative.ID, m.ClearingPrice)
+			bid := OpenRTBBid{
+				ID:         m.ReservationID,
+				ImpID:      input.Imp[i].ID,
+				Price:      m.ClearingCPM.InexactFloat64(),
+				NURL:       h.Signer.winURL(tracking),
+				LURL:       h.Signer.TrackingURL("/t/loss", tracking),
+				CampaignID: m.ID,
+				CreativeID: m.Creative.ID,
+				DealID:     m.DealID,
+				MediaType:  bannerMediaType,
+			}
+			switch {
+			case m.Creative.Format == model.Native:
+				// the native request was validated with the bid request
+				request, _ := input.Imp[i].Native.nativeRequest()
+				markup, err := json.Marshal(nativeMarkup(m.Creative, request,
+					h.Signer.TrackingURL("/t/click", tracking), h.Signer.TrackingURL("/t/imp", tracking)))
+				if err != nil {
+					return nil, err
+				}
+				bid.AdM = string(markup)
+				bid.MediaType = nativeMediaType
+			default:
+				bid.AdM = bannerMarkup(m.Creative, h.Signer.TrackingURL("/t/click", tracking))
+				bid.W = m.Creative.Size.Width
+				bid.H = m.Creative.Size.Height
+			}
+			bids = append(bids, bid)
+		}
+	}
+	return bids, nil
+}
+
+// bannerMarkup returns the markup of the creative: its HTML snippet, or its image linking to the click URL.
+func bannerMarkup(creative model.Creative, clickURL string) string {
+	if creative.HTML != "" {
+		return creative.HTML
+	}
+	return fmt.Sprintf(`<a href="%s" target="_blank"><img src="%s" width="%d" height="%d" alt=""></a>`,
+		html.EscapeString(clickURL), html.EscapeString(creative.ImageURL), creative.Size.Width, creative.Size.Height)
+}
+
+// writeBidResponse answers the bids of the request, or no bid when there is none.
+func writeBidResponse(w http.ResponseWriter, r *http.Request, id string, bids []OpenRTBBid) {
+	if len(bids) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("X-Openrtb-Version", "2.6")
+	pkg.JsonResponse(w, r, http.StatusOK, OpenRTBBidResponse{
+		ID:      id,
+		SeatBid: []OpenRTBSeatBid{{Bid: bids}},
+	})
+}
+
+// parseBidRequest validates the bid request and returns the delivery request of each impression.
+func parseBidRequest(input OpenRTBBidRequest) ([]model.MatchRequest, error) {
+	if input.ID == "" {
+		return nil, fmt.Errorf("missing bid request id")
+	}
+	if len(input.Imp) == 0 {
+		return nil, fmt.Errorf("missing bid request imp")
+	}
+
+	targeting := model.Targeting{
+		Country: model.CountriesISO3[input.Device.Geo.Country],
+		Device:  openRTBDevices[input.Device.DeviceType],
+		OS:      model.OperationalSystems[strings.ToLower(input.Device.OS)],
+	}
+	if alias, ok := openRTBOSAliases[strings.ToLower(input.Device.OS)]; ok {
+		targeting.OS = model.OperationalSystems[alias]
+	}
+
+	userID := input.User.BuyerUID
+	if userID == "" {
+		userID = input.User.ID
+	}
+
+	var publisher string
+	for _, inventory := range []*OpenRTBInventory{input.Site, input.App} {
+		if inventory != nil && inventory.Publisher != nil {
+			publisher = inventory.Publisher.ID
+		}
+	}
+
+	reqs := make([]model.MatchRequest, len(input.Imp))
+	for i, imp := range input.Imp {
+		if imp.BidFloor.IsNegative() {
+			return nil, fmt.Errorf("invalid bidfloor of imp %s: %v", imp.ID, imp.BidFloor)
+		}
+
+		impPublisher := publisher
+		if imp.Ext.Bidder.PublisherID != "" {
+			impPublisher = imp.Ext.Bidder.PublisherID
+		}
+
+		var dealIDs []string
+		var privateAuction bool
+		if imp.PMP != nil {
+			for _, deal := range imp.PMP.Deals {
+				dealIDs = append(dealIDs, deal.ID)
+			}
+			privateAuction = imp.PMP.PrivateAuction == 1
+		}
+
+		// the impressions without banner nor native slot have no formats and are not bid on
+		var formats []model.CreativeFormat
+		if imp.Native != nil {
+			if _, err := imp.Native.nativeRequest(); err != nil {
+				return nil, fmt.Errorf("invalid native request of imp %s: %v", imp.ID, err)
+			}
+			formats = append(formats, model.Native)
+		}
+		if imp.Banner != nil {
+			formats = append(formats, model.Banner)
+		}
+
+		reqs[i] = model.MatchRequest{
+			Targeting: targeting,
+			// the floors of the delivery requests are per delivery
+			BidFloor:       imp.BidFloor.Shift(-3),
+			Formats:        formats,
+			Sizes:          imp.Banner.sizes(),
+			PageViewID:     input.ID,
+			UserID:         userID,
+			Publisher:      impPublisher,
+			DealIDs:        dealIDs,
+			PrivateAuction: privateAuction,
+		}
+	}
+	return reqs, nil
+}
+
+// sizes returns the sizes of the formats of the banner, followed by its w and h when
+// not among them. Any size is accepted without banner.
+func (b *OpenRTBBanner) sizes() []model.Size {
+	if b == nil {
+		return nil
+	}
+
+	var sizes []model.Size
+	for _, f := range b.Format {
+		sizes = append(sizes, model.Size{Width: f.W, Height: f.H})
+	}
+	size := model.Size{Width: b.W, Height: b.H}
+	if b.W > 0 && b.H > 0 && !slices.Contains(sizes, size) {
+		sizes = append(sizes, size)
+	}
+	return sizes
+}
+
+// consented returns whether the request may be bid on: requests subject to the GDPR must carry
+// a consent string granting the consent required by /deliver.
+func (req OpenRTBBidRequest) consented() bool {
+	gdpr := req.Regs.GDPR
+	if gdpr == nil {
+		gdpr = req.Regs.Ext.GDPR
+	}
+	if gdpr == nil || *gdpr != 1 {
+		return true
+	}
+
+	consent := req.User.Consent
+	if consent == "" {
+		consent = req.User.Ext.Consent
+	}
+	hasConsent, err := pkg.CheckConsent(consent, consentVendorID)
+	return err == nil && hasConsent
+}
@@ -0,0 +1,299 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/ports_in"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignsHandler_Bid(t *testing.T) {
+	// String for TCF v2 format with valid consents
+	validConsentString := "CQMGLkAQMGLkABcAKEFRBbFgAP_gAEPgAAqIJnkR_C9MQWFjcT51AfskaYxHxgACo" +
+		"EQgBACJgygBCAPA8IQEwGAYIAxAAqAKAAAAoiRBAAAlCAhQAAAAQAAAACCMAEAAAAAAIKBAgAARAgEACAhB" +
+		"GQAAEAAAAIBBABAAgAAEQBoAQBAAAAAAAAAgAAAgAACBAAAIAAAAAAEAAAAIAEgAAAAAAAAAAAAAAlAIAAA" +
+		"IAAAAAAAAAAAIJngAmChEQAFgQAhAAGEECABQRgAAAAAgAACBggAACAAA4AQAUGAAAAAAAAAIAAAAggABAAA" +
+		"BAAhAAAAAQAAAAAAIAAAAAAAAACBAAAABAAAAAAgAAQAAAAAAAABAABAAgAAAABAAQBAAAAAgAAAAAAAAAAC" +
+		"AAAAAAAAAAAEAAAAIAEAAAAAAAAAAAAAAAAAIAAAAAAAAAAAAAAAAAAA"
+
+	//Valid TCF v2 format, but missing consent
+	missingConsentString := "COtybn4Otybn4AcABBENAPCIAEBAAECAAIAAAAAAAAAAAgAA.YAAAAAAAAAAA"
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("secret"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+	tracking := signer.NewTracking("res1", "camp123", "rectangle", decimal.NewFromFloat(2))
+	creativeTracking := signer.NewTracking("res1", "camp123", "half-page", decimal.NewFromFloat(2))
+	nativeTracking := signer.NewTracking("res1", "camp123", "infeed", decimal.NewFromFloat(2))
+
+	bidRequest := `{
+	"id": "req1",
+	"imp": [
+		{"id": "1", "banner": {"w": 300, "h": 250}, "bidfloor": 1.5, "bidfloorcur": "USD",
+			"pmp": {"private_auction": 1, "deals": [{"id": "deal1", "bidfloor": 2}]}},
+		{"id": "2", "banner": {"w": 728, "h": 90}}
+	],
+	"site": {"id": "site1", "domain": "example.com", "publisher": {"id": "pub1"}},
+	"device": {"ua": "Mozilla/5.0", "ip": "192.0.2.1", "os": "iOS", "devicetype": 4, "geo": {"country": "FRA"}},
+	"user": {"id": "user1", "buyeruid": "buyer1", "consent": "%s"},
+	"regs": {"gdpr": 1},
+	"tmax": 120
+}`
+	iosPhoneInFrance := model.Targeting{Country: model.France, Device: model.Mobile, OS: model.OperationalSystems["ios"]}
+
+	tests := []struct {
+		name         string
+		body         string
+		mockMatches  map[string]model.MatchResult
+		callMatch    int
+		wantReqs     []model.MatchRequest
+		expectedCode int
+		expectedBody *OpenRTBBidResponse
+		expectedErr  string
+	}{
+		{
+			name: "bids on the matched impressions",
+			body: fmt.Sprintf(bidRequest, validConsentString),
+			mockMatches: map[string]model.MatchResult{
+				"deal1": {Matches: []model.CampaignMatch{{ID: "camp123", ClearingPrice: decimal.NewFromFloat(2),
+					ClearingCPM: decimal.NewFromFloat(2), DealID: "deal1", ReservationID: "res1",
+					Creative: model.Creative{ID: "rectangle", Size: model.Size{Width: 300, Height: 250},
+						ImageURL: "https://cdn.example.com/rectangle.png"}}}},
+			},
+			callMatch: 2,
+			wantReqs: []model.MatchRequest{
+				{Targeting: iosPhoneInFrance, BidFloor: decimal.RequireFromString("0.0015"), PageViewID: "req1",
+					UserID: "buyer1", Publisher: "pub1", DealIDs: []string{"deal1"}, PrivateAuction: true,
+					Formats: []model.CreativeFormat{model.Banner}, Sizes: []model.Size{{Width: 300, Height: 250}}},
+				{Targeting: iosPhoneInFrance, BidFloor: decimal.Zero, PageViewID: "req1",
+					UserID: "buyer1", Publisher: "pub1", Formats: []model.CreativeFormat{model.Banner},
+					Sizes: []model.Size{{Width: 728, Height: 90}}},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &OpenRTBBidResponse{ID: "req1", SeatBid: []OpenRTBSeatBid{{Bid: []OpenRTBBid{{
+				ID: "res1", ImpID: "1", Price: 2, CampaignID: "camp123", CreativeID: "rectangle", DealID: "deal1",
+				MediaType: bannerMediaType, W: 300, H: 250,
+				AdM: `<a href="` + strings.ReplaceAll(signer.TrackingURL("/t/click", tracking), "&", "&amp;") +
+					`" target="_blank"><img src="https://cdn.example.com/rectangle.png" ` +
+					`width="300" height="250" alt=""></a>`,
+				NURL: signer.TrackingURL("/t/win", tracking) + "&price=${AUCTION_PRICE}",
+				LURL: signer.TrackingURL("/t/loss", tracking),
+			}}}}},
+		},
+		{
+			name: "bids with the creative fitting the banner formats",
+			body: `{"id": "req1", "imp": [{"id": "1", "banner": {"format": [{"w": 300, "h": 250}, {"w": 300, "h": 600}]},
+				"pmp": {"deals": [{"id": "deal1"}]}}]}`,
+			mockMatches: map[string]model.MatchResult{
+				"deal1": {Matches: []model.CampaignMatch{{ID: "camp123", ClearingPrice: decimal.NewFromFloat(2),
+					ClearingCPM: decimal.NewFromFloat(2), DealID: "deal1", ReservationID: "res1",
+					Creative: model.Creative{ID: "half-page", Size: model.Size{Width: 300, Height: 600},
+						ImageURL: "https://cdn.example.com/half-page.png?v=2&lang=fr"}}}},
+			},
+			callMatch: 1,
+			wantReqs: []model.MatchRequest{
+				{BidFloor: decimal.Zero, PageViewID: "req1", DealIDs: []string{"deal1"},
+					Formats: []model.CreativeFormat{model.Banner},
+					Sizes:   []model.Size{{Width: 300, Height: 250}, {Width: 300, Height: 600}}},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &OpenRTBBidResponse{ID: "req1", SeatBid: []OpenRTBSeatBid{{Bid: []OpenRTBBid{{
+				ID: "res1", ImpID: "1", Price: 2, CampaignID: "camp123", CreativeID: "half-page", DealID: "deal1",
+				MediaType: bannerMediaType, W: 300, H: 600,
+				AdM: `<a href="` + strings.ReplaceAll(signer.TrackingURL("/t/click", creativeTracking), "&", "&amp;") +
+					`" target="_blank"><img src="https://cdn.example.com/half-page.png?v=2&amp;lang=fr" ` +
+					`width="300" height="600" alt=""></a>`,
+				NURL: signer.TrackingURL("/t/win", creativeTracking) + "&price=${AUCTION_PRICE}",
+				LURL: signer.TrackingURL("/t/loss", creativeTracking),
+			}}}}},
+		},
+		{
+			name: "bids with the native response of the native creative to the native request",
+			body: `{"id": "req1", "imp": [{"id": "1", "banner": {"w": 300, "h": 250}, "native": {"ver": "1.2",
+				"request": "{\"ver\":\"1.2\",\"assets\":[{\"id\":1,\"required\":1,\"title\":{\"len\":25}},` +
+				`{\"id\":2,\"required\":1,\"img\":{\"type\":3,\"wmin\":600}},{\"id\":3,\"img\":{\"type\":1}},` +
+				`{\"id\":4,\"data\":{\"type\":2,\"len\":20}},{\"id\":5,\"data\":{\"type\":1}}]}"},
+				"pmp": {"deals": [{"id": "deal1"}]}}]}`,
+			mockMatches: map[string]model.MatchResult{
+				"deal1": {Matches: []model.CampaignMatch{{ID: "camp123", ClearingPrice: decimal.NewFromFloat(2),
+					ClearingCPM: decimal.NewFromFloat(2), DealID: "deal1", ReservationID: "res1",
+					Creative: model.Creative{ID: "infeed", Format: model.Native, Native: model.NativeAd{
+						Title: "Summer sale", Description: "Up to 50% off on the whole summer collection",
+						Image:       model.NativeImage{URL: "https://cdn.example.com/sale.png", Size: model.Size{Width: 1200, Height: 627}},
+						SponsoredBy: "Example"}}}}},
+			},
+			callMatch: 1,
+			wantReqs: []model.MatchRequest{
+				{BidFloor: decimal.Zero, PageViewID: "req1", DealIDs: []string{"deal1"},
+					Formats: []model.CreativeFormat{model.Native, model.Banner}, Sizes: []model.Size{{Width: 300, Height: 250}}},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &OpenRTBBidResponse{ID: "req1", SeatBid: []OpenRTBSeatBid{{Bid: []OpenRTBBid{{
+				ID: "res1", ImpID: "1", Price: 2, CampaignID: "camp123", CreativeID: "infeed", DealID: "deal1",
+				MediaType: nativeMediaType,
+				AdM: `{"ver":"1.2","assets":[{"id":1,"title":{"text":"Summer sale"}},` +
+					`{"id":2,"img":{"url":"https://cdn.example.com/sale.png","w":1200,"h":627}},` +
+					`{"id":5,"data":{"value":"Example"}}],` +
+					`"link":{"url":"` + signer.TrackingURL("/t/click", nativeTracking) + `"},` +
+					`"eventtrackers":[{"event":1,"method":1,"url":"` + signer.TrackingURL("/t/imp", nativeTracking) + `"}]}`,
+				NURL: signer.TrackingURL("/t/win", nativeTracking) + "&price=${AUCTION_PRICE}",
+				LURL: signer.TrackingURL("/t/loss", nativeTracking),
+			}}}}},
+		},
+		{
+			name:         "invalid native request",
+			body:         `{"id": "req1", "imp": [{"id": "1", "native": {"request": "{\"assets\": 1}"}}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "invalid native request of imp 1",
+		},
+		{
+			name:         "no bid without match",
+			body:         fmt.Sprintf(bidRequest, validConsentString),
+			callMatch:    2,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "no bid without consent when the gdpr applies",
+			body:         fmt.Sprintf(bidRequest, missingConsentString),
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "no bid with an invalid consent string when the gdpr applies",
+			body:         fmt.Sprintf(bidRequest, "invalid"),
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "consent and gdpr of the extensions before OpenRTB 2.6, unknown targeting matching any",
+			body: fmt.Sprintf(`{"id": "req1", "imp": [{"id": "1", "banner": {}}], "app": {"publisher": {"id": "pub2"}},
+				"device": {"os": "Tizen", "devicetype": 3, "geo": {"country": "DEU"}},
+				"user": {"id": "user1", "ext": {"consent": "%s"}}, "regs": {"ext": {"gdpr": 1}}}`, validConsentString),
+			callMatch: 1,
+			wantReqs: []model.MatchRequest{
+				{BidFloor: decimal.Zero, PageViewID: "req1", UserID: "user1", Publisher: "pub2",
+					Formats: []model.CreativeFormat{model.Banner}},
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:      "no consent needed outside the gdpr",
+			body:      `{"id": "req1", "imp": [{"id": "1", "banner": {}}], "device": {"os": "Android", "devicetype": 2}, "regs": {"gdpr": 0}}`,
+			callMatch: 1,
+			wantReqs: []model.MatchRequest{
+				{Targeting: model.Targeting{Device: model.Desktop, OS: model.Android}, BidFloor: decimal.Zero,
+					PageViewID: "req1", Formats: []model.CreativeFormat{model.Banner}},
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "no bid on the impressions without banner nor native slot",
+			body: `{"id": "req1", "imp": [{"id": "1", "video": {"mimes": ["video/mp4"]},
+				"pmp": {"deals": [{"id": "deal1"}]}}]}`,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "missing imp",
+			body:         `{"id": "req1", "imp": []}`,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing bid request imp",
+		},
+		{
+			name:         "invalid bid floor",
+			body:         `{"id": "req1", "imp": [{"id": "1", "bidfloor": -1}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "invalid bidfloor of imp 1: -1",
+		},
+		{
+			name:         "invalid payload",
+			body:         `{"id": 1}`,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "invalid request payload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				MatchFunc: func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
+					if len(req.DealIDs) == 0 {
+						return model.MatchResult{}, pkg.Errorf(pkg.ENOTFOUND, "no campaign found")
+					}
+					return tt.mockMatches[req.DealIDs[0]], nil
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
+
+			req := httptest.NewRequest(http.MethodPost, "/openrtb2/bid", bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+
+			handler.bid(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			calls := campaignServiceMock.MatchCalls()
+			assert.Len(t, calls, tt.callMatch)
+			for i, want := range tt.wantReqs {
+				got := calls[i].Req
+				assert.True(t, want.BidFloor.Equal(got.BidFloor), "bid floor is %s, want %s", got.BidFloor, want.BidFloor)
+				got.BidFloor = want.BidFloor
+				assert.Equal(t, want, got)
+			}
+			if tt.expectedBody != nil {
+				assert.Equal(t, "2.6", rec.Header().Get("X-Openrtb-Version"))
+				var got OpenRTBBidResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(t, *tt.expectedBody, got)
+			}
+			if tt.expectedErr != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedErr)
+			}
+		})
+	}
+
+	t.Run("answers the bids made before tmax elapsed", func(t *testing.T) {
+		campaignServiceMock := &ports_in.CampaignServiceMock{
+			MatchFunc: func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
+				if req.Sizes[0].Width == 728 {
+					// the match of the second impression outlasts tmax
+					<-ctx.Done()
+					return model.MatchResult{}, ctx.Err()
+				}
+				return model.MatchResult{Matches: []model.CampaignMatch{{ID: "camp123",
+					ClearingPrice: decimal.NewFromFloat(2), ClearingCPM: decimal.NewFromFloat(2), ReservationID: "res1",
+					Creative: model.Creative{ID: "half-page", Size: model.Size{Width: 300, Height: 600},
+						ImageURL: "https://cdn.example.com/half-page.png"}}}}, nil
+			},
+		}
+		handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
+
+		req := httptest.NewRequest(http.MethodPost, "/openrtb2/bid", bytes.NewBufferString(`{"id": "req1", "imp": [
+			{"id": "1", "banner": {"w": 300, "h": 600}}, {"id": "2", "banner": {"w": 728, "h": 90}},
+			{"id": "3", "banner": {"w": 300, "h": 600}}], "tmax": 20}`))
+		rec := httptest.NewRecorder()
+
+		handler.bid(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Len(t, campaignServiceMock.MatchCalls(), 2)
+		var got OpenRTBBidResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, OpenRTBBidResponse{ID: "req1", SeatBid: []OpenRTBSeatBid{{Bid: []OpenRTBBid{{
+			ID: "res1", ImpID: "1", Price: 2, CampaignID: "camp123", CreativeID: "half-page",
+			MediaType: bannerMediaType, W: 300, H: 600,
+			AdM: `<a href="` + strings.ReplaceAll(signer.TrackingURL("/t/click", creativeTracking), "&", "&amp;") +
+				`" target="_blank"><img src="https://cdn.example.com/half-page.png" ` +
+				`width="300" height="600" alt=""></a>`,
+			NURL: signer.TrackingURL("/t/win", creativeTracking) + "&price=${AUCTION_PRICE}",
+			LURL: signer.TrackingURL("/t/loss", creativeTracking),
+		}}}}}, got)
+	})
+}
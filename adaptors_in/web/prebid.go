@@ -0,0 +1,46 @@
+package web
+
+import (
+	"net/http"
+
+	"ad-campaign-delivery/pkg"
+)
+
+// prebidTypes maps the OpenRTB media types of the bids onto their Prebid media types.
+var prebidTypes = map[int]string{
+	bannerMediaType: "banner",
+	nativeMediaType: "native",
+}
+
+// @Summary      Bid as a Prebid Server bidder
+// @Description  Bids on the OpenRTB 2.6 requests of the Prebid Server bidder adapter like /openrtb2/bid, answering
+// @Description  the Prebid media type of each bid in its ext.prebid.type. The publisher_id of the bidder params in
+// @Description  imp.ext.bidder identifies the publisher of the deals. The consent string passed through by Prebid
+// @Description  Server in user.ext.consent, or user.consent, is checked when regs.ext.gdpr, or regs.gdpr, is 1,
+// @Description  and the impressions left once tmax elapsed are not bid on.
+// @Tags         openrtb
+// @Accept       json
+// @Produce      json
+// @Param        request  body      OpenRTBBidRequest   true  "OpenRTB 2.6 bid request of Prebid Server"
+// @Success      200      {object}  OpenRTBBidResponse  "Bids on the impressions"
+// @Success      204      "No bid"
+// @Failure      400      {object}  pkg.ErrorResp
+// @Failure      500      {object}  pkg.ErrorResp
+// @Router       /prebid/bid [post]
+func (h *CampaignsHandler) prebidBid(w http.ResponseWriter, r *http.Request) {
+	input, reqs, ok := decodeBidRequest(w, r)
+	if !ok {
+		return
+	}
+
+	bids, err := h.bidOnImpressions(r.Context(), input, reqs)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	for i := range bids {
+		bids[i].Ext = &OpenRTBBidExt{Prebid: PrebidBidExt{Type: prebidTypes[bids[i].MediaType]}}
+	}
+	writeBidResponse(w, r, input.ID, bids)
+}
@@ -0,0 +1,133 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/ports_in"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignsHandler_PrebidBid(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("secret"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+
+	iosPhoneInFrance := model.Targeting{Country: model.France, Device: model.Mobile, OS: model.OperationalSystems["ios"]}
+	match := model.MatchResult{Matches: []model.CampaignMatch{{ID: "camp123", ClearingPrice: decimal.NewFromFloat(2),
+		ClearingCPM: decimal.NewFromFloat(2.5), ReservationID: "res1",
+		Creative: model.Creative{ID: "medium-rectangle", Size: model.Size{Width: 300, Height: 250},
+			HTML: `<div class="ad"><a href="https://example.com/landing">Shop now</a></div>`}}}}
+	nativeMatch := model.MatchResult{Matches: []model.CampaignMatch{{ID: "camp123", ClearingPrice: decimal.NewFromFloat(2),
+		ClearingCPM: decimal.NewFromFloat(2.5), ReservationID: "res2",
+		Creative: model.Creative{ID: "infeed", Format: model.Native, Native: model.NativeAd{
+			Title:       "Summer sale: up to 50% off",
+			Description: "The whole summer collection, delivered for free.",
+			Icon:        model.NativeImage{URL: "https://cdn.example.com/logo.png", Size: model.Size{Width: 80, Height: 80}},
+			Image:       model.NativeImage{URL: "https://cdn.example.com/sale.jpg", Size: model.Size{Width: 1200, Height: 627}},
+			CTA:         "Shop now",
+			SponsoredBy: "Example Store",
+		}}}}}
+
+	tests := []struct {
+		name         string
+		request      string
+		matchFunc    func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error)
+		callMatch    int
+		wantReqs     []model.MatchRequest
+		expectedCode int
+		response     string
+	}{
+		{
+			name:    "bids on the impressions of the publisher of the bidder params",
+			request: "banner_request.json",
+			matchFunc: func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
+				if req.BidFloor.IsZero() {
+					return model.MatchResult{}, pkg.Errorf(pkg.ENOTFOUND, "no campaign found")
+				}
+				return match, nil
+			},
+			callMatch: 2,
+			wantReqs: []model.MatchRequest{
+				{Targeting: iosPhoneInFrance, BidFloor: decimal.RequireFromString("0.0015"),
+					PageViewID: "b8b4b4a5-0b39-4d4f-9c54-5b8e1b7f2c11", UserID: "3f2a9c1e-buyer", Publisher: "pub1",
+					Formats: []model.CreativeFormat{model.Banner},
+					Sizes:   []model.Size{{Width: 300, Height: 250}, {Width: 300, Height: 600}}},
+				{Targeting: iosPhoneInFrance, BidFloor: decimal.Zero,
+					PageViewID: "b8b4b4a5-0b39-4d4f-9c54-5b8e1b7f2c11", UserID: "3f2a9c1e-buyer", Publisher: "pub1",
+					Formats: []model.CreativeFormat{model.Banner}, Sizes: []model.Size{{Width: 728, Height: 90}}},
+			},
+			expectedCode: http.StatusOK,
+			response:     "banner_response.json",
+		},
+		{
+			name:    "bids on the native impression with the native response",
+			request: "native_request.json",
+			matchFunc: func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
+				return nativeMatch, nil
+			},
+			callMatch: 1,
+			wantReqs: []model.MatchRequest{
+				{Targeting: iosPhoneInFrance, BidFloor: decimal.RequireFromString("0.001"),
+					PageViewID: "5c1d2e3f-7a8b-4c9d-8e0f-1a2b3c4d5e6f", UserID: "3f2a9c1e-buyer", Publisher: "pub1",
+					Formats: []model.CreativeFormat{model.Native}},
+			},
+			expectedCode: http.StatusOK,
+			response:     "native_response.json",
+		},
+		{
+			name:    "no bid on the impressions left once tmax elapsed",
+			request: "banner_request.json",
+			matchFunc: func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
+				<-ctx.Done()
+				return model.MatchResult{}, ctx.Err()
+			},
+			callMatch:    1,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "no bid without consent when the gdpr applies",
+			request:      "gdpr_without_consent_request.json",
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{MatchFunc: tt.matchFunc}
+			handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
+
+			body, err := os.ReadFile(filepath.Join("testdata", "prebid", tt.request))
+			assert.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/prebid/bid", bytes.NewBuffer(body))
+			rec := httptest.NewRecorder()
+
+			handler.prebidBid(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			calls := campaignServiceMock.MatchCalls()
+			assert.Len(t, calls, tt.callMatch)
+			for i, want := range tt.wantReqs {
+				got := calls[i].Req
+				assert.True(t, want.BidFloor.Equal(got.BidFloor), "bid floor is %s, want %s", got.BidFloor, want.BidFloor)
+				got.BidFloor = want.BidFloor
+				assert.Equal(t, want, got)
+			}
+			if tt.response != "" {
+				expected, err := os.ReadFile(filepath.Join("testdata", "prebid", tt.response))
+				assert.NoError(t, err)
+				assert.Equal(t, "2.6", rec.Header().Get("X-Openrtb-Version"))
+				assert.JSONEq(t, string(expected), rec.Body.String())
+			}
+		})
+	}
+}
@@ -2,12 +2,30 @@ package web
 
 import (
 	"net/http"
+	"time"
 
 	"ad-campaign-delivery/ports_in"
 )
 
-func ConfigureCampaignRoutes(u ports_in.CampaignService, r *http.ServeMux) {
-	campaignHandler := CampaignsHandler{UseCase: u}
+func ConfigureCampaignRoutes(u ports_in.CampaignService, signer URLSigner, exposureTTL time.Duration, r *http.ServeMux) {
+	campaignHandler := CampaignsHandler{UseCase: u, Signer: signer, ExposureTTL: exposureTTL}
 	r.HandleFunc("POST /campaigns", campaignHandler.create)
 	r.HandleFunc("POST /deliver", campaignHandler.match)
+	r.HandleFunc("POST /deliver/explain", campaignHandler.explain)
+	r.HandleFunc("POST /deliver/vast", campaignHandler.deliverVAST)
+	r.HandleFunc("POST /openrtb2/bid", campaignHandler.bid)
+	r.HandleFunc("POST /prebid/bid", campaignHandler.prebidBid)
+	r.HandleFunc("GET /t/imp", campaignHandler.trackImpression)
+	r.HandleFunc("GET /t/click", campaignHandler.trackClick)
+	r.HandleFunc("GET /t/conv", campaignHandler.trackConversion)
+	r.HandleFunc("GET /t/video", campaignHandler.trackVideoEvent)
+	r.HandleFunc("GET /t/win", campaignHandler.trackWin)
+	r.HandleFunc("GET /t/loss", campaignHandler.trackLoss)
+	r.HandleFunc("POST /campaigns/{id}/creatives", campaignHandler.addCreative)
+	r.HandleFunc("GET /campaigns/{id}/creatives", campaignHandler.listCreatives)
+	r.HandleFunc("DELETE /campaigns/{id}/creatives/{creative_id}", campaignHandler.removeCreative)
+	r.HandleFunc("POST /campaigns/{id}/creatives/{creative_id}/approve", campaignHandler.approveCreative)
+	r.HandleFunc("POST /campaigns/{id}/creatives/{creative_id}/reject", campaignHandler.rejectCreative)
+	r.HandleFunc("PUT /floor-rules", campaignHandler.setFloorRule)
+	r.HandleFunc("PUT /deals", campaignHandler.setDeal)
 }
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"github.com/shopspring/decimal"
+)
+
+// URLSigner signs the tracking tokens of the URLs returned to the clients, so they cannot be forged.
+type URLSigner struct {
+	// BaseURL is the public URL of the service, e.g. https://ads.example.com.
+	BaseURL string
+	Secret  []byte
+	// TTL is how long the tracking URLs of a delivery are accepted.
+	TTL time.Duration
+	// now returns the current time, time.Now when unset.
+	now func() time.Time
+}
+
+// NewTracking returns the tracking of the delivery reserved for the campaign with the creative at
+// the clearing price, expiring after the TTL.
+func (s URLSigner) NewTracking(reservationID, campaignID, creativeID string, clearingPrice decimal.Decimal) model.Tracking {
+	return model.Tracking{ReservationID: reservationID, CampaignID: campaignID, CreativeID: creativeID,
+		ClearingPrice: clearingPrice, ExpiresAt: s.currentTime().Add(s.TTL)}
+}
+
+// TrackingURL returns the URL of the path on the service, with the token of the tracking signed for
+// this path, so a URL of the delivery cannot be turned into another one.
+func (s URLSigner) TrackingURL(path string, tracking model.Tracking) string {
+	return s.BaseURL + path + "?" + url.Values{"t": {s.token(path, tracking)}}.Encode()
+}
+
+// token encodes the endpoint the token is accepted on and the fields of the tracking, followed by
+// their signature. The creative ID is escaped so it cannot contain the separator.
+func (s URLSigner) token(endpoint string, tracking model.Tracking) string {
+	fields := []string{endpoint, tracking.ReservationID, strconv.FormatInt(tracking.ExpiresAt.Unix(), 10),
+		tracking.ClearingPrice.String(), url.PathEscape(tracking.CreativeID), tracking.CampaignID}
+	payload := base64.RawURLEncoding.EncodeToString([]byte(strings.Join(fields, "|")))
+	return payload + "." + pkg.Sign(s.Secret, payload)
+}
+
+// Tracking returns the tracking of the token in the t query parameter of the request,
+// failing when its signature is invalid, it was signed for another endpoint or it expired.
+func (s URLSigner) Tracking(r *http.Request, endpoint string) (model.Tracking, error) {
+	payload, signature, _ := strings.Cut(r.URL.Query().Get("t"), ".")
+	if !pkg.ValidSignature(s.Secret, payload, signature) {
+		return model.Tracking{}, pkg.Errorf(pkg.EFORBIDDEN, "invalid tracking token")
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	// the campaign ID comes last, as it may contain the separator
+	fields := strings.SplitN(string(decoded), "|", 6)
+	if err != nil || len(fields) != 6 || fields[0] != endpoint {
+		return model.Tracking{}, pkg.Errorf(pkg.EFORBIDDEN, "invalid tracking token")
+	}
+	fields = fields[1:]
+	expiresAt, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return model.Tracking{}, pkg.Errorf(pkg.EFORBIDDEN, "invalid tracking token")
+	}
+	clearingPrice, err := decimal.NewFromString(fields[2])
+	if err != nil {
+		return model.Tracking{}, pkg.Errorf(pkg.EFORBIDDEN, "invalid tracking token")
+	}
+	creativeID, err := url.PathUnescape(fields[3])
+	if err != nil {
+		return model.Tracking{}, pkg.Errorf(pkg.EFORBIDDEN, "invalid tracking token")
+	}
+
+	tracking := model.Tracking{ReservationID: fields[0], CampaignID: fields[4], CreativeID: creativeID,
+		ClearingPrice: clearingPrice, ExpiresAt: time.Unix(expiresAt, 0)}
+	if !tracking.ExpiresAt.After(s.currentTime()) {
+		return model.Tracking{}, pkg.Errorf(pkg.EFORBIDDEN, "expired tracking token")
+	}
+	return tracking, nil
+}
+
+func (s URLSigner) currentTime() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
@@ -0,0 +1,249 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"github.com/shopspring/decimal"
+)
+
+// auctionPriceMacro is substituted in the win notices by the clearing price of the upstream auction.
+const auctionPriceMacro = "${AUCTION_PRICE}"
+
+// winURL returns the win notice of the tracked delivery, whose price is substituted by the upstream exchange.
+func (s URLSigner) winURL(tracking model.Tracking) string {
+	return s.TrackingURL("/t/win", tracking) + "&price=" + auctionPriceMacro
+}
+
+// videoEventURL returns the tracking URL of the playback event of the tracked video delivery, whose token
+// is only accepted for this event.
+func (s URLSigner) videoEventURL(tracking model.Tracking, event model.EventType) string {
+	return s.BaseURL + "/t/video?" + url.Values{"t": {s.token(videoEndpoint(event), tracking)}}.Encode() +
+		"&event=" + string(event)
+}
+
+// videoEndpoint is the endpoint the tokens of the video event URLs are signed for.
+func videoEndpoint(event model.EventType) string {
+	return "/t/video?event=" + string(event)
+}
+
+// pixel is a transparent 1x1 GIF, answered to the tracking pixels.
+var pixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// @Summary      Track an impression
+// @Description  Confirms the delivery reserved by /deliver when its ad is rendered, charging it for good.
+// @Description  Called through the impression_url of the delivery before the reservation expires: the cost
+// @Description  of the deliveries left unconfirmed is released back to the campaign budget.
+// @Description  Repeated pings of the same delivery are ignored.
+// @Tags         tracking
+// @Produce      image/gif
+// @Param        t    query  string  true  "Signed tracking token of the delivery"
+// @Success      200  "Transparent pixel"
+// @Failure      403  {object}  pkg.ErrorResp
+// @Failure      404  {object}  pkg.ErrorResp
+// @Failure      500  {object}  pkg.ErrorResp
+// @Router       /t/imp [get]
+func (h *CampaignsHandler) trackImpression(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tracking, err := h.Signer.Tracking(r, "/t/imp")
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	err = h.UseCase.ConfirmDelivery(ctx, tracking.ReservationID)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	writePixel(w)
+}
+
+// @Summary      Track a click
+// @Description  Records the click of the delivery, charged to cpc campaigns, and redirects to the
+// @Description  click_url of the creative delivered, or of the campaign when the creative has none.
+// @Description  Called through the click_url of the delivery, where repeated clicks of the same delivery
+// @Description  are redirected without being recorded again.
+// @Tags         tracking
+// @Param        t    query  string  true  "Signed tracking token of the delivery"
+// @Success      302  "Redirect to the click_url of the creative or the campaign"
+// @Success      204  "Click recorded, neither the creative nor the campaign has a click_url (no content)"
+// @Failure      403  {object}  pkg.ErrorResp
+// @Failure      404  {object}  pkg.ErrorResp
+// @Failure      500  {object}  pkg.ErrorResp
+// @Router       /t/click [get]
+func (h *CampaignsHandler) trackClick(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tracking, err := h.Signer.Tracking(r, "/t/click")
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	campaign, err := h.UseCase.TrackEvent(ctx, tracking, model.Click)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	if campaign.ClickURL == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, campaign.ClickURL, http.StatusFound)
+}
+
+// @Summary      Track a conversion
+// @Description  Records the conversion of the delivery, charged to cpa campaigns. Called through the
+// @Description  conversion_url of the delivery, where repeated conversions of the same delivery are ignored.
+// @Tags         tracking
+// @Produce      image/gif
+// @Param        t    query  string  true  "Signed tracking token of the delivery"
+// @Success      200  "Transparent pixel"
+// @Failure      403  {object}  pkg.ErrorResp
+// @Failure      404  {object}  pkg.ErrorResp
+// @Failure      500  {object}  pkg.ErrorResp
+// @Router       /t/conv [get]
+func (h *CampaignsHandler) trackConversion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tracking, err := h.Signer.Tracking(r, "/t/conv")
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	_, err = h.UseCase.TrackEvent(ctx, tracking, model.Conversion)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	writePixel(w)
+}
+
+// @Summary      Track a video event
+// @Description  Records the playback event of the video delivered by /deliver/vast, free of charge.
+// @Description  Called through the tracking events of the VAST document, where repeated events of the same
+// @Description  delivery are ignored.
+// @Tags         tracking
+// @Produce      image/gif
+// @Param        t      query  string  true  "Signed tracking token of the delivery"
+// @Param        event  query  string  true  "Playback event" Enums(start, firstQuartile, midpoint, thirdQuartile, complete)
+// @Success      200  "Transparent pixel"
+// @Failure      400  {object}  pkg.ErrorResp
+// @Failure      403  {object}  pkg.ErrorResp
+// @Failure      404  {object}  pkg.ErrorResp
+// @Failure      500  {object}  pkg.ErrorResp
+// @Router       /t/video [get]
+func (h *CampaignsHandler) trackVideoEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input := r.URL.Query().Get("event")
+	event, ok := model.VideoEvents[input]
+	if !ok {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid event: %v", input))
+		return
+	}
+
+	tracking, err := h.Signer.Tracking(r, videoEndpoint(event))
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	_, err = h.UseCase.TrackEvent(ctx, tracking, event)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	writePixel(w)
+}
+
+// @Summary      Notify a win
+// @Description  Confirms the delivery reserved by a bid of /openrtb2/bid or /prebid/bid on the win notice of the
+// @Description  exchange, charging the clearing price of its auction instead of the reserved cost, and never more.
+// @Description  Called through the nurl of the bid, with the ${AUCTION_PRICE} macro substituted by the clearing
+// @Description  price per thousand deliveries.
+// @Description  Win notices of confirmed deliveries are ignored, and the impression_url is not needed after a win.
+// @Tags         tracking
+// @Param        t      query  string  true  "Signed tracking token of the delivery"
+// @Param        price  query  string  true  "Clearing price per thousand deliveries of the upstream auction"
+// @Success      204  "Delivery confirmed (no content)"
+// @Failure      400  {object}  pkg.ErrorResp
+// @Failure      403  {object}  pkg.ErrorResp
+// @Failure      404  {object}  pkg.ErrorResp
+// @Failure      500  {object}  pkg.ErrorResp
+// @Router       /t/win [get]
+func (h *CampaignsHandler) trackWin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tracking, err := h.Signer.Tracking(r, "/t/win")
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	input := r.URL.Query().Get("price")
+	price, err := decimal.NewFromString(input)
+	if err != nil || price.IsNegative() {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid price: %v", input))
+		return
+	}
+
+	err = h.UseCase.WinDelivery(ctx, tracking.ReservationID, price)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Notify a loss
+// @Description  Releases the delivery reserved by a bid of /openrtb2/bid or /prebid/bid on the loss notice of the
+// @Description  exchange, refunding its cost to the campaign budget. Called through the lurl of the bid.
+// @Description  Loss notices of confirmed deliveries are ignored.
+// @Tags         tracking
+// @Param        t    query  string  true  "Signed tracking token of the delivery"
+// @Success      204  "Delivery released (no content)"
+// @Failure      403  {object}  pkg.ErrorResp
+// @Failure      404  {object}  pkg.ErrorResp
+// @Failure      500  {object}  pkg.ErrorResp
+// @Router       /t/loss [get]
+func (h *CampaignsHandler) trackLoss(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tracking, err := h.Signer.Tracking(r, "/t/loss")
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	err = h.UseCase.ReleaseDelivery(ctx, tracking.ReservationID)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writePixel answers the transparent pixel, never cached so every ping reaches the service.
+func writePixel(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "image/gif")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(pixel)
+}
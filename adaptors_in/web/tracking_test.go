@@ -0,0 +1,404 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/ports_in"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignsHandler_TrackImpression(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("secret"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+	otherSigner := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("other"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+	tracking := signer.NewTracking("res1", "camp123", "", decimal.NewFromFloat(2))
+	// the payload of another reservation with the signature of the tracking
+	otherPayload, _, _ := strings.Cut(signer.token("/t/imp", signer.NewTracking("res2", "camp123", "", decimal.NewFromFloat(2))), ".")
+	_, signature, _ := strings.Cut(signer.token("/t/imp", tracking), ".")
+
+	tests := []struct {
+		name         string
+		url          string
+		callConfirm  bool
+		confirmErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "successful confirmation",
+			url:          signer.TrackingURL("/t/imp", tracking),
+			callConfirm:  true,
+			expectedCode: http.StatusOK,
+			expectedBody: string(pixel),
+		},
+		{
+			name:         "missing token",
+			url:          "https://ads.example.com/t/imp",
+			expectedCode: http.StatusForbidden,
+			expectedBody: "invalid tracking token",
+		},
+		{
+			name:         "token with another secret",
+			url:          otherSigner.TrackingURL("/t/imp", tracking),
+			expectedCode: http.StatusForbidden,
+			expectedBody: "invalid tracking token",
+		},
+		{
+			name:         "token of another reservation",
+			url:          "https://ads.example.com/t/imp?t=" + otherPayload + "." + signature,
+			expectedCode: http.StatusForbidden,
+			expectedBody: "invalid tracking token",
+		},
+		{
+			name: "expired token",
+			url: signer.TrackingURL("/t/imp",
+				model.Tracking{ReservationID: "res1", CampaignID: "camp123", ExpiresAt: now.Add(-time.Second)}),
+			expectedCode: http.StatusForbidden,
+			expectedBody: "expired tracking token",
+		},
+		{
+			name:         "expired reservation",
+			url:          signer.TrackingURL("/t/imp", tracking),
+			callConfirm:  true,
+			confirmErr:   pkg.Errorf(pkg.ENOTFOUND, "reservation with ID res1 not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: "reservation with ID res1 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				ConfirmDeliveryFunc: func(ctx context.Context, reservationID string) error {
+					assert.Equal(t, "res1", reservationID)
+					return tt.confirmErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			handler.trackImpression(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.callConfirm, len(campaignServiceMock.ConfirmDeliveryCalls()) == 1)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, "image/gif", rec.Header().Get("Content-Type"))
+				assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+func TestCampaignsHandler_TrackClick(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("secret"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+	tracking := signer.NewTracking("res1", "camp123", "", decimal.NewFromFloat(2))
+	// the creative ID may contain the separator of the token fields
+	creativeTracking := signer.NewTracking("res1", "camp123", "banner|300x250", decimal.NewFromFloat(2))
+
+	tests := []struct {
+		name             string
+		url              string
+		wantCreativeID   string
+		callTrack        bool
+		mockCampaign     model.Campaign
+		trackErr         error
+		expectedCode     int
+		expectedLocation string
+		expectedBody     string
+	}{
+		{
+			name:             "redirects to the click url of the campaign",
+			url:              signer.TrackingURL("/t/click", tracking),
+			callTrack:        true,
+			mockCampaign:     model.Campaign{ID: "camp123", ClickURL: "https://example.com/landing"},
+			expectedCode:     http.StatusFound,
+			expectedLocation: "https://example.com/landing",
+		},
+		{
+			name:             "redirects to the click url of the tracked creative",
+			url:              signer.TrackingURL("/t/click", creativeTracking),
+			wantCreativeID:   "banner|300x250",
+			callTrack:        true,
+			mockCampaign:     model.Campaign{ID: "camp123", ClickURL: "https://example.com/banner"},
+			expectedCode:     http.StatusFound,
+			expectedLocation: "https://example.com/banner",
+		},
+		{
+			name:         "campaign without click url",
+			url:          signer.TrackingURL("/t/click", tracking),
+			callTrack:    true,
+			mockCampaign: model.Campaign{ID: "camp123"},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "invalid token",
+			url:          "https://ads.example.com/t/click?t=forged",
+			expectedCode: http.StatusForbidden,
+			expectedBody: "invalid tracking token",
+		},
+		{
+			name:         "campaign not found",
+			url:          signer.TrackingURL("/t/click", tracking),
+			callTrack:    true,
+			trackErr:     pkg.Errorf(pkg.ENOTFOUND, "campaign with ID camp123 not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: "campaign with ID camp123 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				TrackEventFunc: func(ctx context.Context, got model.Tracking, event model.EventType) (model.Campaign, error) {
+					assert.Equal(t, tracking.ReservationID, got.ReservationID)
+					assert.Equal(t, tracking.CampaignID, got.CampaignID)
+					assert.Equal(t, tt.wantCreativeID, got.CreativeID)
+					assert.True(t, tracking.ExpiresAt.Equal(got.ExpiresAt))
+					assert.Equal(t, model.Click, event)
+					return tt.mockCampaign, tt.trackErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			handler.trackClick(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.callTrack, len(campaignServiceMock.TrackEventCalls()) == 1)
+			assert.Equal(t, tt.expectedLocation, rec.Header().Get("Location"))
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCampaignsHandler_TrackConversion(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("secret"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+	tracking := signer.NewTracking("res1", "camp123", "", decimal.NewFromFloat(2))
+
+	campaignServiceMock := &ports_in.CampaignServiceMock{
+		TrackEventFunc: func(ctx context.Context, got model.Tracking, event model.EventType) (model.Campaign, error) {
+			assert.Equal(t, tracking.ReservationID, got.ReservationID)
+			assert.Equal(t, tracking.CampaignID, got.CampaignID)
+			assert.True(t, tracking.ExpiresAt.Equal(got.ExpiresAt))
+			assert.Equal(t, model.Conversion, event)
+			return model.Campaign{ID: "camp123"}, nil
+		},
+	}
+	handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
+
+	req := httptest.NewRequest(http.MethodGet, signer.TrackingURL("/t/conv", tracking), nil)
+	rec := httptest.NewRecorder()
+
+	handler.trackConversion(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, campaignServiceMock.TrackEventCalls(), 1)
+	assert.Equal(t, pixel, rec.Body.Bytes())
+
+	// the token of the click URL of the delivery is not accepted for its conversion
+	clickURL := signer.TrackingURL("/t/click", tracking)
+	req = httptest.NewRequest(http.MethodGet, strings.Replace(clickURL, "/t/click", "/t/conv", 1), nil)
+	rec = httptest.NewRecorder()
+
+	handler.trackConversion(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid tracking token")
+	assert.Len(t, campaignServiceMock.TrackEventCalls(), 1)
+}
+
+func TestCampaignsHandler_TrackVideoEvent(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("secret"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+	tracking := signer.NewTracking("res1", "camp123", "preroll", decimal.NewFromFloat(2))
+
+	tests := []struct {
+		name         string
+		url          string
+		wantEvent    model.EventType
+		trackErr     error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "quartile recorded",
+			url:          signer.videoEventURL(tracking, model.FirstQuartile),
+			wantEvent:    model.FirstQuartile,
+			expectedCode: http.StatusOK,
+			expectedBody: string(pixel),
+		},
+		{
+			name:         "unknown event",
+			url:          signer.TrackingURL("/t/video", tracking) + "&event=click",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid event: click",
+		},
+		{
+			name:         "token of another event",
+			url:          strings.Replace(signer.videoEventURL(tracking, model.Start), "event=start", "event=complete", 1),
+			expectedCode: http.StatusForbidden,
+			expectedBody: "invalid tracking token",
+		},
+		{
+			name:         "invalid token",
+			url:          "https://ads.example.com/t/video?t=forged&event=start",
+			expectedCode: http.StatusForbidden,
+			expectedBody: "invalid tracking token",
+		},
+		{
+			name:         "campaign not found",
+			url:          signer.videoEventURL(tracking, model.Complete),
+			wantEvent:    model.Complete,
+			trackErr:     pkg.Errorf(pkg.ENOTFOUND, "campaign with ID camp123 not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: "campaign with ID camp123 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				TrackEventFunc: func(ctx context.Context, got model.Tracking, event model.EventType) (model.Campaign, error) {
+					assert.Equal(t, tracking.ReservationID, got.ReservationID)
+					assert.Equal(t, tracking.CreativeID, got.CreativeID)
+					assert.Equal(t, tt.wantEvent, event)
+					return model.Campaign{ID: "camp123"}, tt.trackErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			handler.trackVideoEvent(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.wantEvent != "", len(campaignServiceMock.TrackEventCalls()) == 1)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCampaignsHandler_TrackWin(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("secret"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+	winURL := signer.TrackingURL("/t/win", signer.NewTracking("res1", "camp123", "", decimal.NewFromFloat(2))) + "&price="
+
+	tests := []struct {
+		name         string
+		url          string
+		callWin      bool
+		wantPrice    decimal.Decimal
+		winErr       error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "charges the clearing price",
+			url:          winURL + "1.25",
+			callWin:      true,
+			wantPrice:    decimal.NewFromFloat(1.25),
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "macro not substituted",
+			url:          winURL + auctionPriceMacro,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid price: ${AUCTION_PRICE}",
+		},
+		{
+			name:         "negative price",
+			url:          winURL + "-1",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid price: -1",
+		},
+		{
+			name:         "invalid token",
+			url:          "https://ads.example.com/t/win?t=forged&price=1.25",
+			expectedCode: http.StatusForbidden,
+			expectedBody: "invalid tracking token",
+		},
+		{
+			name:         "expired reservation",
+			url:          winURL + "1.25",
+			callWin:      true,
+			wantPrice:    decimal.NewFromFloat(1.25),
+			winErr:       pkg.Errorf(pkg.ENOTFOUND, "reservation with ID res1 not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: "reservation with ID res1 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				WinDeliveryFunc: func(ctx context.Context, reservationID string, price decimal.Decimal) error {
+					assert.Equal(t, "res1", reservationID)
+					assert.True(t, tt.wantPrice.Equal(price))
+					return tt.winErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			handler.trackWin(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.callWin, len(campaignServiceMock.WinDeliveryCalls()) == 1)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCampaignsHandler_TrackLoss(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("secret"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+
+	campaignServiceMock := &ports_in.CampaignServiceMock{
+		ReleaseDeliveryFunc: func(ctx context.Context, reservationID string) error {
+			assert.Equal(t, "res1", reservationID)
+			return nil
+		},
+	}
+	handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
+
+	req := httptest.NewRequest(http.MethodGet, signer.TrackingURL("/t/loss", signer.NewTracking("res1", "camp123", "", decimal.NewFromFloat(2))), nil)
+	rec := httptest.NewRecorder()
+
+	handler.trackLoss(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Len(t, campaignServiceMock.ReleaseDeliveryCalls(), 1)
+}
@@ -0,0 +1,203 @@
+package web
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+)
+
+const (
+	vastVersion = "4.2"
+	// vastAdSystem names the ad server in the VAST documents, and the registry of their universal ad IDs.
+	vastAdSystem = "ad-campaign-delivery"
+)
+
+// videoEvents are the playback events tracked in the VAST documents, in playback order.
+var videoEvents = []model.EventType{model.Start, model.FirstQuartile, model.Midpoint, model.ThirdQuartile, model.Complete}
+
+// VAST is a VAST 4.2 document, holding an inline ad per video delivered, played in sequence when more
+// than one slot is requested, and no ad when no campaign matched.
+type VAST struct {
+	XMLName xml.Name `xml:"VAST"`
+	Version string   `xml:"version,attr"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	Ads     []VASTAd `xml:"Ad"`
+}
+
+type VASTAd struct {
+	ID       string     `xml:"id,attr"`
+	Sequence int        `xml:"sequence,attr,omitempty"`
+	InLine   VASTInLine `xml:"InLine"`
+}
+
+type VASTInLine struct {
+	AdSystem    string         `xml:"AdSystem"`
+	AdTitle     string         `xml:"AdTitle"`
+	AdServingID string         `xml:"AdServingId"`
+	Error       VASTURL        `xml:"Error"`
+	Impression  VASTURL        `xml:"Impression"`
+	Creatives   []VASTCreative `xml:"Creatives>Creative"`
+}
+
+// VASTURL is a URL, wrapped in a CDATA section as the VAST documents do.
+type VASTURL struct {
+	URL string `xml:",cdata"`
+}
+
+type VASTCreative struct {
+	ID            string            `xml:"id,attr"`
+	AdID          string            `xml:"adId,attr"`
+	UniversalAdID VASTUniversalAdID `xml:"UniversalAdId"`
+	Linear        VASTLinear        `xml:"Linear"`
+}
+
+type VASTUniversalAdID struct {
+	IDRegistry string `xml:"idRegistry,attr"`
+	ID         string `xml:",chardata"`
+}
+
+type VASTLinear struct {
+	Duration       string          `xml:"Duration"`
+	MediaFiles     []VASTMediaFile `xml:"MediaFiles>MediaFile"`
+	ClickThrough   VASTURL         `xml:"VideoClicks>ClickThrough"`
+	TrackingEvents []VASTTracking  `xml:"TrackingEvents>Tracking"`
+}
+
+type VASTMediaFile struct {
+	Delivery string `xml:"delivery,attr"`
+	Type     string `xml:"type,attr"`
+	Width    int    `xml:"width,attr"`
+	Height   int    `xml:"height,attr"`
+	Bitrate  int    `xml:"bitrate,attr,omitempty"`
+	URL      string `xml:",cdata"`
+}
+
+type VASTTracking struct {
+	Event string `xml:"event,attr"`
+	URL   string `xml:",cdata"`
+}
+
+// @Summary      Deliver a video ad
+// @Description  Delivers the video creative of the campaign matching the request like /deliver, as a VAST 4.2
+// @Description  document for the video player. Only campaigns with a video creative are delivered, the formats
+// @Description  and sizes of the request being ignored as the player picks the media file suiting it.
+// @Description  The impression, click-through and playback events of the document point at the tracking
+// @Description  endpoints, besides the third-party tracking URLs of the creative, and the error URL releases
+// @Description  the delivery. When slots is set, the videos delivered form an ad pod played in sequence.
+// @Description  An empty VAST document is answered when no campaign matches, with the reason in the
+// @Description  X-No-Match-Reason header.
+// @Tags         deliveries
+// @Accept       json
+// @Produce      xml
+// @Param        X-Consent-String  header    string                true  "TCF v2 consent string"
+// @Param        request           body      CampaignMatchRequest  true  "Delivery request"
+// @Success      200               {object}  VAST                  "VAST document"
+// @Failure      400               {object}  pkg.ErrorResp
+// @Failure      500               {object}  pkg.ErrorResp
+// @Router       /deliver/vast [post]
+func (h *CampaignsHandler) deliverVAST(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !checkConsent(w, r) {
+		return
+	}
+
+	input := CampaignMatchRequest{}
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, fmt.Sprintf("invalid request payload: %v", err))
+		return
+	}
+
+	req, err := parseMatchRequest(input)
+	if err != nil {
+		pkg.BadRequestResponse(w, r, err.Error())
+		return
+	}
+	req.Formats = []model.CreativeFormat{model.Video}
+	req.Sizes = nil
+
+	result, err := h.UseCase.Match(ctx, req)
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+
+	vast := VAST{Version: vastVersion, XMLNS: "http://www.iab.com/VAST"}
+	if len(result.Matches) == 0 {
+		w.Header().Set("X-No-Match-Reason", string(result.NoMatchReason))
+	}
+	for i, m := range result.Matches {
+		ad := h.vastAd(m)
+		if input.Slots > 0 {
+			ad.Sequence = i + 1
+		}
+		vast.Ads = append(vast.Ads, ad)
+	}
+
+	writeXML(w, r, vast)
+}
+
+// vastAd returns the inline ad of the video delivered to the campaign match.
+func (h *CampaignsHandler) vastAd(m model.CampaignMatch) VASTAd {
+	tracking := h.Signer.NewTracking(m.ReservationID, m.ID, m.Creative.ID, m.ClearingPrice)
+	video := m.Creative.Video
+
+	linear := VASTLinear{
+		Duration:     vastDuration(video.Duration),
+		ClickThrough: VASTURL{URL: h.Signer.TrackingURL("/t/click", tracking)},
+	}
+	for _, f := range video.MediaFiles {
+		linear.MediaFiles = append(linear.MediaFiles, VASTMediaFile{Delivery: "progressive", Type: f.MIMEType,
+			Width: f.Width, Height: f.Height, Bitrate: f.Bitrate, URL: f.URL})
+	}
+	for _, event := range videoEvents {
+		linear.TrackingEvents = append(linear.TrackingEvents,
+			VASTTracking{Event: string(event), URL: h.Signer.videoEventURL(tracking, event)})
+		for _, u := range video.TrackingEvents[event] {
+			linear.TrackingEvents = append(linear.TrackingEvents, VASTTracking{Event: string(event), URL: u})
+		}
+	}
+
+	return VASTAd{
+		ID: m.ID,
+		InLine: VASTInLine{
+			AdSystem:    vastAdSystem,
+			AdTitle:     m.ID,
+			AdServingID: m.ReservationID,
+			Error:       VASTURL{URL: h.Signer.TrackingURL("/t/loss", tracking)},
+			Impression:  VASTURL{URL: h.Signer.TrackingURL("/t/imp", tracking)},
+			Creatives: []VASTCreative{{
+				ID:            m.Creative.ID,
+				AdID:          m.ID,
+				UniversalAdID: VASTUniversalAdID{IDRegistry: vastAdSystem, ID: m.Creative.ID},
+				Linear:        linear,
+			}},
+		},
+	}
+}
+
+// vastDuration formats the duration as HH:MM:SS.mmm.
+func vastDuration(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3_600_000, ms/60_000%60, ms/1000%60, ms%1000)
+}
+
+// writeXML answers the document, indented, with the XML header.
+func writeXML(w http.ResponseWriter, r *http.Request, document any) {
+	body, err := xml.MarshalIndent(document, "", "\t")
+	if err != nil {
+		pkg.ErrorResponse(w, r, err)
+		return
+	}
+	body = append([]byte(xml.Header), append(body, '\n')...)
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -0,0 +1,148 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/ports_in"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignsHandler_DeliverVAST(t *testing.T) {
+	// String for TCF v2 format with valid consents
+	validConsentString := "CQMGLkAQMGLkABcAKEFRBbFgAP_gAEPgAAqIJnkR_C9MQWFjcT51AfskaYxHxgACo" +
+		"EQgBACJgygBCAPA8IQEwGAYIAxAAqAKAAAAoiRBAAAlCAhQAAAAQAAAACCMAEAAAAAAIKBAgAARAgEACAhB" +
+		"GQAAEAAAAIBBABAAgAAEQBoAQBAAAAAAAAAgAAAgAACBAAAIAAAAAAEAAAAIAEgAAAAAAAAAAAAAAlAIAAA" +
+		"IAAAAAAAAAAAIJngAmChEQAFgQAhAAGEECABQRgAAAAAgAACBggAACAAA4AQAUGAAAAAAAAAIAAAAggABAAA" +
+		"BAAhAAAAAQAAAAAAIAAAAAAAAACBAAAABAAAAAAgAAQAAAAAAAABAABAAgAAAABAAQBAAAAAgAAAAAAAAAAC" +
+		"AAAAAAAAAAAEAAAAIAEAAAAAAAAAAAAAAAAAIAAAAAAAAAAAAAAAAAAA"
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := URLSigner{BaseURL: "https://ads.example.com", Secret: []byte("secret"), TTL: time.Hour,
+		now: func() time.Time { return now }}
+
+	preroll := model.Creative{ID: "preroll", CampaignID: "camp123", Format: model.Video,
+		Video: model.LinearVideo{
+			Duration: 15 * time.Second,
+			MediaFiles: []model.MediaFile{
+				{URL: "https://cdn.example.com/preroll-720p.mp4", MIMEType: "video/mp4", Width: 1280, Height: 720, Bitrate: 2500},
+				{URL: "https://cdn.example.com/preroll-360p.webm", MIMEType: "video/webm", Width: 640, Height: 360},
+			},
+			TrackingEvents: map[model.EventType][]string{model.Complete: {"https://tracker.example.com/complete?ad=1&c=2"}},
+		}}
+	outro := model.Creative{ID: "outro", CampaignID: "camp456", Format: model.Video,
+		Video: model.LinearVideo{Duration: 6500 * time.Millisecond, MediaFiles: []model.MediaFile{
+			{URL: "https://cdn.example.com/outro.mp4", MIMEType: "video/mp4", Width: 640, Height: 360}}}}
+
+	tests := []struct {
+		name          string
+		consentToken  string
+		input         CampaignMatchRequest
+		callMatch     bool
+		mockResult    model.MatchResult
+		mockErr       error
+		expectedCode  int
+		response      string
+		expectedBody  string
+		noMatchReason string
+	}{
+		{
+			name:         "video of the winning campaign",
+			consentToken: validConsentString,
+			input:        CampaignMatchRequest{Country: "FR", Device: "desktop", OS: "windows", Sizes: []string{"640x360"}},
+			callMatch:    true,
+			mockResult: model.MatchResult{Matches: []model.CampaignMatch{{ID: "camp123",
+				ClearingPrice: decimal.NewFromFloat(2), ReservationID: "res1", Creative: preroll}}},
+			expectedCode: http.StatusOK,
+			response:     "preroll.xml",
+		},
+		{
+			name:         "ad pod of the slots played in sequence",
+			consentToken: validConsentString,
+			input:        CampaignMatchRequest{Country: "FR", Device: "desktop", OS: "windows", Slots: 2},
+			callMatch:    true,
+			mockResult: model.MatchResult{Matches: []model.CampaignMatch{
+				{ID: "camp123", ReservationID: "res1", Creative: preroll},
+				{ID: "camp456", ReservationID: "res2", Creative: outro},
+			}},
+			expectedCode: http.StatusOK,
+			expectedBody: `<Ad id="camp456" sequence="2">`,
+		},
+		{
+			name:          "empty document without match",
+			consentToken:  validConsentString,
+			input:         CampaignMatchRequest{Country: "FR", Device: "desktop", OS: "windows"},
+			callMatch:     true,
+			mockResult:    model.MatchResult{NoMatchReason: model.NoCreative},
+			expectedCode:  http.StatusOK,
+			expectedBody:  `<VAST version="4.2" xmlns="http://www.iab.com/VAST"></VAST>`,
+			noMatchReason: "no_creative",
+		},
+		{
+			name:         "missing consent string",
+			input:        CampaignMatchRequest{Country: "FR", Device: "desktop", OS: "windows"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "missing header X-Consent-String",
+		},
+		{
+			name:         "invalid country",
+			consentToken: validConsentString,
+			input:        CampaignMatchRequest{Country: "invalid_country", Device: "desktop", OS: "windows"},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid country: invalid_country",
+		},
+		{
+			name:         "match failure",
+			consentToken: validConsentString,
+			input:        CampaignMatchRequest{Country: "FR", Device: "desktop", OS: "windows"},
+			callMatch:    true,
+			mockErr:      pkg.Errorf(pkg.EINTERNAL, "storage unavailable"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignServiceMock := &ports_in.CampaignServiceMock{
+				MatchFunc: func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
+					assert.Equal(t, []model.CreativeFormat{model.Video}, req.Formats)
+					assert.Empty(t, req.Sizes)
+					return tt.mockResult, tt.mockErr
+				},
+			}
+			handler := CampaignsHandler{UseCase: campaignServiceMock, Signer: signer}
+
+			body, _ := json.Marshal(tt.input)
+			req := httptest.NewRequest(http.MethodPost, "/deliver/vast", bytes.NewBuffer(body))
+			if tt.consentToken != "" {
+				req.Header.Set("X-Consent-String", tt.consentToken)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.deliverVAST(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.callMatch, len(campaignServiceMock.MatchCalls()) == 1)
+			assert.Equal(t, tt.noMatchReason, rec.Header().Get("X-No-Match-Reason"))
+			if tt.response != "" {
+				expected, err := os.ReadFile(filepath.Join("testdata", "vast", tt.response))
+				assert.NoError(t, err)
+				assert.Equal(t, "application/xml", rec.Header().Get("Content-Type"))
+				assert.Equal(t, string(expected), rec.Body.String())
+			}
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package in_memory
+
+import (
+	"context"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+)
+
+// impression is the event type tracking the confirmation of the deliveries.
+const impression model.EventType = "impression"
+
+// ConfirmDelivery confirms the reserved delivery on its impression, counting it in the campaign
+// and targeting stats. Impression goal campaigns are deactivated once their goal is delivered.
+// Repeated confirmations are ignored until the reservation expires, and unknown or expired
+// reservations are not found.
+func (r *CampaignRepository) ConfirmDelivery(ctx context.Context, reservationID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.confirmed(reservationID) {
+		return nil
+	}
+
+	d, err := r.pendingReservation(reservationID)
+	if err != nil {
+		return err
+	}
+
+	r.confirm(d)
+	return nil
+}
+
+// confirmed returns whether the delivery of the reservation was already confirmed.
+// It must be called with the lock held.
+func (r *CampaignRepository) confirmed(reservationID string) bool {
+	_, tracked := r.trackedEvents[trackedEvent{reservationID: reservationID, event: impression}]
+	return tracked
+}
+
+// pendingReservation returns the reservation, which is not found when unknown or expired.
+// It must be called with the lock held.
+func (r *CampaignRepository) pendingReservation(reservationID string) (model.Delivery, error) {
+	d, ok := r.reservations[reservationID]
+	// expired reservations are left to ReleaseExpiredDeliveries, which releases their cost
+	if !ok || !d.ExpiresAt.After(time.Now()) {
+		return model.Delivery{}, pkg.Errorf(pkg.ENOTFOUND, "reservation with ID %s not found", reservationID)
+	}
+	return d, nil
+}
+
+// confirm counts the delivery of the reservation in the campaign and targeting stats, and
+// remembers its confirmation until the reservation expires. It must be called with the write lock held.
+func (r *CampaignRepository) confirm(d model.Delivery) {
+	delete(r.reservations, d.ReservationID)
+	r.trackedEvents[trackedEvent{reservationID: d.ReservationID, event: impression}] = d.ExpiresAt
+
+	campaign := r.campaigns[d.CampaignID]
+	targeting := campaign.Targeting()
+
+	stats := r.stats[d.CampaignID]
+	stats.Deliveries++
+	r.stats[d.CampaignID] = stats
+
+	if campaign.ImpressionGoal > 0 && stats.Deliveries >= campaign.ImpressionGoal {
+		campaign.Active = false
+		r.campaigns[d.CampaignID] = campaign
+	}
+
+	stats = r.targetingStats[targeting]
+	stats.Deliveries++
+	r.targetingStats[targeting] = stats
+}
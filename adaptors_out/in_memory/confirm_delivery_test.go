@@ -0,0 +1,105 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_ConfirmDelivery(t *testing.T) {
+	targeting := model.Targeting{Country: model.France, Device: model.Mobile, OS: model.Android}
+	expiresAt := time.Now().Add(5 * time.Minute)
+
+	tests := []struct {
+		name          string
+		campaign      model.Campaign
+		reservation   model.Delivery
+		reservationID string
+		wantActive    bool
+		wantStats     model.DeliveryStats
+		wantErr       error
+	}{
+		{
+			name: "counts the confirmed delivery",
+			campaign: model.Campaign{ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+				Active: true, Bid: decimal.NewFromFloat(5), Budget: decimal.NewFromFloat(95)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: expiresAt},
+			reservationID: "r1",
+			wantActive:    true,
+			wantStats:     model.DeliveryStats{Deliveries: 1},
+		},
+		{
+			name: "impression goal campaign is deactivated once its goal is delivered",
+			campaign: model.Campaign{ID: "goal", Country: model.France, Device: model.Mobile, OS: model.Android,
+				Active: true, Bid: decimal.NewFromFloat(1), Priority: model.Guaranteed, ImpressionGoal: 1},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "goal",
+				Cost: decimal.Zero, ExpiresAt: expiresAt},
+			reservationID: "r1",
+			wantActive:    false,
+			wantStats:     model.DeliveryStats{Deliveries: 1},
+		},
+		{
+			name: "expired reservation",
+			campaign: model.Campaign{ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+				Active: true, Bid: decimal.NewFromFloat(5), Budget: decimal.NewFromFloat(95)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: time.Now().Add(-time.Second)},
+			reservationID: "r1",
+			wantActive:    true,
+			wantErr:       pkg.Errorf(pkg.ENOTFOUND, "reservation with ID r1 not found"),
+		},
+		{
+			name: "unknown reservation",
+			campaign: model.Campaign{ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+				Active: true, Bid: decimal.NewFromFloat(5), Budget: decimal.NewFromFloat(95)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: expiresAt},
+			reservationID: "r2",
+			wantActive:    true,
+			wantErr:       pkg.Errorf(pkg.ENOTFOUND, "reservation with ID r2 not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			repo.campaigns[tt.campaign.ID] = tt.campaign
+			repo.reservations[tt.reservation.ReservationID] = tt.reservation
+
+			err := repo.ConfirmDelivery(context.Background(), tt.reservationID)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.NotContains(t, repo.reservations, tt.reservationID)
+			}
+			assert.Equal(t, tt.wantActive, repo.campaigns[tt.campaign.ID].Active)
+			assert.Equal(t, tt.wantStats, repo.stats[tt.campaign.ID])
+			assert.Equal(t, tt.wantStats, repo.targetingStats[targeting])
+		})
+	}
+
+	t.Run("confirmed only once", func(t *testing.T) {
+		l := logger.Init()
+		repo := NewCampaignRepository(&l)
+		repo.campaigns["1"] = model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5),
+			Budget: decimal.NewFromFloat(95)}
+		repo.reservations["r1"] = model.Delivery{ReservationID: "r1", CampaignID: "1",
+			Cost: decimal.NewFromFloat(5), ExpiresAt: expiresAt}
+
+		assert.NoError(t, repo.ConfirmDelivery(context.Background(), "r1"))
+		// repeated impressions of the delivery are ignored
+		assert.NoError(t, repo.ConfirmDelivery(context.Background(), "r1"))
+		assert.Equal(t, int64(1), repo.stats["1"].Deliveries)
+	})
+}
@@ -10,8 +10,8 @@ import (
 // CreateCampaign inserts a new campaign into the in-memory store.
 // All data must have already been validated in the domain.
 func (r *CampaignRepository) CreateCampaign(ctx context.Context, campaign model.Campaign) error {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
 	if _, ok := r.campaigns[campaign.ID]; ok {
 		return pkg.Errorf(pkg.ECONFLICT, "campaign with ID %s already exists", campaign.ID)
@@ -22,6 +22,7 @@ func (r *CampaignRepository) CreateCampaign(ctx context.Context, campaign model.
 		r.createTargetingKeys(campaign)
 	}
 	r.insertBidInLookup(campaign)
+	r.insertInSlotsLookup(campaign)
 	return nil
 }
 
@@ -64,3 +65,29 @@ func (r *CampaignRepository) insertBidInLookup(campaign model.Campaign) {
 		append([]model.BidLookup{newBid}, orderedBids[low:]...)...,
 	)
 }
+
+// insertInSlotsLookup indexes the campaign under its formats and sizes, or under the
+// empty format and size when it allows any.
+func (r *CampaignRepository) insertInSlotsLookup(campaign model.Campaign) {
+	formats := campaign.Formats
+	if len(formats) == 0 {
+		formats = []model.CreativeFormat{""}
+	}
+	for _, format := range formats {
+		if _, ok := r.slotsLookup.Formats[format]; !ok {
+			r.slotsLookup.Formats[format] = map[string]bool{}
+		}
+		r.slotsLookup.Formats[format][campaign.ID] = true
+	}
+
+	sizes := campaign.Sizes
+	if len(sizes) == 0 {
+		sizes = []model.Size{{}}
+	}
+	for _, size := range sizes {
+		if _, ok := r.slotsLookup.Sizes[size]; !ok {
+			r.slotsLookup.Sizes[size] = map[string]bool{}
+		}
+		r.slotsLookup.Sizes[size][campaign.ID] = true
+	}
+}
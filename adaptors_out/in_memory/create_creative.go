@@ -0,0 +1,34 @@
+package in_memory
+
+import (
+	"context"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+)
+
+// CreateCreative appends the creative to the creatives of its campaign.
+func (r *CampaignRepository) CreateCreative(ctx context.Context, creative model.Creative) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.campaigns[creative.CampaignID]; !ok {
+		return pkg.Errorf(pkg.ENOTFOUND, "campaign with ID %s not found", creative.CampaignID)
+	}
+	if _, ok := r.findCreative(creative.CampaignID, creative.ID); ok {
+		return pkg.Errorf(pkg.ECONFLICT, "creative with ID %s already exists", creative.ID)
+	}
+
+	r.creatives[creative.CampaignID] = append(r.creatives[creative.CampaignID], creative)
+	return nil
+}
+
+// findCreative returns the creative of the campaign with the given ID.
+func (r *CampaignRepository) findCreative(campaignID, creativeID string) (model.Creative, bool) {
+	for _, creative := range r.creatives[campaignID] {
+		if creative.ID == creativeID {
+			return creative, true
+		}
+	}
+	return model.Creative{}, false
+}
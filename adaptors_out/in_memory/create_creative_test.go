@@ -0,0 +1,81 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_CreateAndFindCreatives(t *testing.T) {
+	banner := model.Creative{ID: "banner", CampaignID: "1", Size: model.Size{Width: 300, Height: 250},
+		ImageURL: "https://cdn.example.com/banner.png", ClickURL: "https://example.com/landing"}
+	snippet := model.Creative{ID: "snippet", CampaignID: "1", Size: model.Size{Width: 728, Height: 90},
+		HTML: "<div>ad</div>"}
+
+	tests := []struct {
+		name          string
+		existing      []model.Creative
+		creative      model.Creative
+		wantCreatives []model.Creative
+		wantErr       error
+	}{
+		{
+			name:          "first creative of the campaign",
+			creative:      banner,
+			wantCreatives: []model.Creative{banner},
+		},
+		{
+			name:          "creatives in creation order",
+			existing:      []model.Creative{banner},
+			creative:      snippet,
+			wantCreatives: []model.Creative{banner, snippet},
+		},
+		{
+			name:          "duplicate creative ID",
+			existing:      []model.Creative{banner},
+			creative:      model.Creative{ID: "banner", CampaignID: "1", HTML: "<div>ad</div>"},
+			wantCreatives: []model.Creative{banner},
+			wantErr:       pkg.Errorf(pkg.ECONFLICT, "creative with ID banner already exists"),
+		},
+		{
+			name:          "unknown campaign",
+			creative:      model.Creative{ID: "banner", CampaignID: "2", HTML: "<div>ad</div>"},
+			wantCreatives: nil,
+			wantErr:       pkg.Errorf(pkg.ENOTFOUND, "campaign with ID 2 not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			repo.campaigns["1"] = model.Campaign{ID: "1"}
+			repo.creatives["1"] = tt.existing
+
+			err := repo.CreateCreative(context.Background(), tt.creative)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			creatives, err := repo.FindCreatives(context.Background(), "1")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCreatives, creatives)
+		})
+	}
+
+	t.Run("creatives of an unknown campaign", func(t *testing.T) {
+		l := logger.Init()
+		repo := NewCampaignRepository(&l)
+
+		_, err := repo.FindCreatives(context.Background(), "2")
+		assert.Equal(t, pkg.ENOTFOUND, pkg.ErrorCode(err))
+	})
+}
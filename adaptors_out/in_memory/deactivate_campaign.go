@@ -5,8 +5,8 @@ import (
 )
 
 func (r *CampaignRepository) DeactivateExpiredCampaigns() {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
 	for id, c := range r.campaigns {
 		if c.Active && c.ExpiresAt.Before(time.Now()) {
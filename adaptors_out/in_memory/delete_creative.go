@@ -0,0 +1,28 @@
+package in_memory
+
+import (
+	"context"
+	"slices"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+)
+
+// DeleteCreative removes the creative from the creatives of its campaign.
+func (r *CampaignRepository) DeleteCreative(ctx context.Context, campaignID, creativeID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.findCreative(campaignID, creativeID); !ok {
+		return pkg.Errorf(pkg.ENOTFOUND, "creative with ID %s not found", creativeID)
+	}
+
+	// the candidates found before still hold the previous slice
+	r.creatives[campaignID] = slices.DeleteFunc(slices.Clone(r.creatives[campaignID]), func(c model.Creative) bool {
+		return c.ID == creativeID
+	})
+	if len(r.creatives[campaignID]) == 0 {
+		delete(r.creatives, campaignID)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_DeleteCreative(t *testing.T) {
+	banner := model.Creative{ID: "banner", CampaignID: "1", Size: model.Size{Width: 300, Height: 250},
+		ImageURL: "https://cdn.example.com/banner.png"}
+	snippet := model.Creative{ID: "snippet", CampaignID: "1", Size: model.Size{Width: 728, Height: 90},
+		HTML: "<div>ad</div>"}
+
+	tests := []struct {
+		name          string
+		campaignID    string
+		creativeID    string
+		wantCreatives model.Creatives
+		wantErr       error
+	}{
+		{
+			name:          "keeps the order of the other creatives",
+			campaignID:    "1",
+			creativeID:    "banner",
+			wantCreatives: model.Creatives{"1": {snippet}},
+		},
+		{
+			name:          "unknown creative",
+			campaignID:    "1",
+			creativeID:    "unknown",
+			wantCreatives: model.Creatives{"1": {banner, snippet}},
+			wantErr:       pkg.Errorf(pkg.ENOTFOUND, "creative with ID unknown not found"),
+		},
+		{
+			name:          "creative of another campaign",
+			campaignID:    "2",
+			creativeID:    "banner",
+			wantCreatives: model.Creatives{"1": {banner, snippet}},
+			wantErr:       pkg.Errorf(pkg.ENOTFOUND, "creative with ID banner not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			repo.campaigns["1"] = model.Campaign{ID: "1"}
+			creatives := []model.Creative{banner, snippet}
+			repo.creatives["1"] = creatives
+
+			err := repo.DeleteCreative(context.Background(), tt.campaignID, tt.creativeID)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCreatives, repo.creatives)
+			// the creatives already found are left untouched
+			assert.Equal(t, []model.Creative{banner, snippet}, creatives)
+		})
+	}
+
+	t.Run("last creative of the campaign", func(t *testing.T) {
+		l := logger.Init()
+		repo := NewCampaignRepository(&l)
+		repo.creatives["1"] = []model.Creative{banner}
+
+		assert.NoError(t, repo.DeleteCreative(context.Background(), "1", "banner"))
+		assert.Empty(t, repo.creatives)
+	})
+}
@@ -0,0 +1,36 @@
+package in_memory
+
+import (
+	"slices"
+	"time"
+)
+
+// DeleteExpiredExposures drops the deliveries older than the ttl, the users left
+// without any delivery, and the page views without delivery within the page view ttl.
+func (r *ExposureRepository) DeleteExpiredExposures() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	since := time.Now().Add(-r.ttl)
+	for userID, exposures := range r.exposures {
+		for campaignID, times := range exposures {
+			unexpired := unexpiredTimes(times, since)
+			if len(unexpired) == 0 {
+				delete(exposures, campaignID)
+				continue
+			}
+			// cloned so the expired times are not kept by the backing array
+			exposures[campaignID] = slices.Clone(unexpired)
+		}
+		if len(exposures) == 0 {
+			delete(r.exposures, userID)
+		}
+	}
+
+	pageViewSince := time.Now().Add(-r.pageViewTTL)
+	for pageViewID, view := range r.pageViews {
+		if !view.deliveredAt.After(pageViewSince) {
+			delete(r.pageViews, pageViewID)
+		}
+	}
+}
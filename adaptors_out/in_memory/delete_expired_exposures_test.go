@@ -0,0 +1,37 @@
+package in_memory
+
+import (
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExposureRepository_DeleteExpiredExposures(t *testing.T) {
+	now := time.Now()
+	repo := NewExposureRepository(24*time.Hour, 30*time.Minute)
+	repo.exposures = map[string]model.Exposures{
+		"user1": {
+			"camp1": {now.Add(-48 * time.Hour), now.Add(-time.Hour)},
+			"camp2": {now.Add(-30 * time.Hour)},
+		},
+		"user2": {
+			"camp1": {now.Add(-25 * time.Hour)},
+		},
+	}
+
+	repo.pageViews = map[string]pageView{
+		"view1": {categories: model.PageView{"automotive": "acme"}, deliveredAt: now.Add(-time.Minute)},
+		"view2": {categories: model.PageView{"travel": "initech"}, deliveredAt: now.Add(-time.Hour)},
+	}
+
+	repo.DeleteExpiredExposures()
+
+	assert.Equal(t, map[string]model.Exposures{
+		"user1": {"camp1": {now.Add(-time.Hour)}},
+	}, repo.exposures)
+	assert.Equal(t, map[string]pageView{
+		"view1": {categories: model.PageView{"automotive": "acme"}, deliveredAt: now.Add(-time.Minute)},
+	}, repo.pageViews)
+}
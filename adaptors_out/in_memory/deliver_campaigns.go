@@ -0,0 +1,55 @@
+package in_memory
+
+import (
+	"context"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"github.com/shopspring/decimal"
+)
+
+// DeliverCampaigns reserves the deliveries until their impression: the cost of each delivery is
+// deducted from the campaign budget, and the delivery is only counted in the campaign and targeting
+// stats once confirmed. The cost is capped at the remaining budget, which bid modifiers above 1 could
+// exceed. All deliveries are reserved in the same operation: if any campaign was deactivated meanwhile,
+// none of them is reserved.
+func (r *CampaignRepository) DeliverCampaigns(ctx context.Context, deliveries []model.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range deliveries {
+		if !r.campaigns[d.CampaignID].Active {
+			return pkg.Errorf(pkg.ECONFLICT, "campaign with ID %s can no longer be delivered", d.CampaignID)
+		}
+	}
+
+	for _, d := range deliveries {
+		if campaign := r.campaigns[d.CampaignID]; campaign.Budgeted() {
+			d.Cost = decimal.Min(d.Cost, campaign.Budget)
+		}
+		r.deductBudget(d.CampaignID, d.Cost)
+		r.reservations[d.ReservationID] = d
+	}
+	return nil
+}
+
+// deductBudget deducts the cost from the campaign budget, deactivating the campaign when the
+// remaining budget cannot afford its unit cost. It must be called with the write lock held.
+func (r *CampaignRepository) deductBudget(campaignID string, cost decimal.Decimal) {
+	campaign, ok := r.campaigns[campaignID]
+	// this should not happen
+	if !ok {
+		r.log.Error().
+			Str("campaign_id", campaignID).
+			Msg("attempt to deduct budget for non-existent campaign")
+		return
+	}
+
+	campaign.Budget = campaign.Budget.Sub(cost)
+
+	if campaign.UnitCost().GreaterThan(campaign.Budget) {
+		campaign.Active = false
+	}
+
+	r.campaigns[campaignID] = campaign
+}
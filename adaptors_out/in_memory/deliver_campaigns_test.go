@@ -0,0 +1,132 @@
+package in_memory
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_DeliverCampaigns(t *testing.T) {
+	expiresAt := time.Now().Add(5 * time.Minute)
+
+	tests := []struct {
+		name         string
+		campaigns    model.Campaigns
+		deliveries   []model.Delivery
+		wantBudgets  map[string]decimal.Decimal
+		wantActive   map[string]bool
+		wantReserved []string
+		// wantCosts holds the cost reserved by reservation ID, when checked
+		wantCosts map[string]decimal.Decimal
+		wantErr   error
+	}{
+		{
+			name: "reserves the cost of each delivery",
+			campaigns: model.Campaigns{
+				"1": {ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+					Active: true, Bid: decimal.NewFromFloat(5), Budget: decimal.NewFromFloat(100)},
+				"2": {ID: "2", Country: model.France, Device: model.Mobile, OS: model.Android,
+					Active: true, Bid: decimal.NewFromFloat(4), PricingModel: model.CPM,
+					Budget: decimal.RequireFromString("0.007")},
+			},
+			deliveries: []model.Delivery{
+				{ReservationID: "r1", CampaignID: "1", Cost: decimal.NewFromFloat(4.01), ExpiresAt: expiresAt},
+				{ReservationID: "r2", CampaignID: "2", Cost: decimal.RequireFromString("0.0035"), ExpiresAt: expiresAt},
+			},
+			wantBudgets: map[string]decimal.Decimal{
+				"1": decimal.NewFromFloat(95.99),
+				"2": decimal.RequireFromString("0.0035"),
+			},
+			// campaign 2 cannot afford another cpm delivery of 0.004
+			wantActive:   map[string]bool{"1": true, "2": false},
+			wantReserved: []string{"r1", "r2"},
+		},
+		{
+			name: "cost raised by a bid modifier is capped at the remaining budget",
+			campaigns: model.Campaigns{
+				"1": {ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+					Active: true, Bid: decimal.NewFromFloat(10), Budget: decimal.NewFromFloat(10),
+					BidModifiers: model.BidModifiers{Country: map[model.Country]decimal.Decimal{
+						model.France: decimal.NewFromFloat(3)}}},
+			},
+			deliveries:   []model.Delivery{{ReservationID: "r1", CampaignID: "1", Cost: decimal.NewFromFloat(30), ExpiresAt: expiresAt}},
+			wantBudgets:  map[string]decimal.Decimal{"1": decimal.Zero},
+			wantActive:   map[string]bool{"1": false},
+			wantReserved: []string{"r1"},
+			wantCosts:    map[string]decimal.Decimal{"r1": decimal.NewFromFloat(10)},
+		},
+		{
+			name: "house campaign stays active without budget",
+			campaigns: model.Campaigns{
+				"house": {ID: "house", Country: model.France, Device: model.Mobile, OS: model.Android,
+					Active: true, Bid: decimal.NewFromFloat(1), Priority: model.House, Budget: decimal.Zero},
+			},
+			deliveries:   []model.Delivery{{ReservationID: "r1", CampaignID: "house", Cost: decimal.Zero, ExpiresAt: expiresAt}},
+			wantBudgets:  map[string]decimal.Decimal{"house": decimal.Zero},
+			wantActive:   map[string]bool{"house": true},
+			wantReserved: []string{"r1"},
+		},
+		{
+			name: "nothing is reserved when a campaign is no longer active",
+			campaigns: model.Campaigns{
+				"1": {ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+					Active: true, Bid: decimal.NewFromFloat(5), Budget: decimal.NewFromFloat(100)},
+				"2": {ID: "2", Country: model.France, Device: model.Mobile, OS: model.Android,
+					Active: false, Bid: decimal.NewFromFloat(4), Budget: decimal.NewFromFloat(1)},
+			},
+			deliveries: []model.Delivery{
+				{ReservationID: "r1", CampaignID: "1", Cost: decimal.NewFromFloat(4), ExpiresAt: expiresAt},
+				{ReservationID: "r2", CampaignID: "2", Cost: decimal.NewFromFloat(4), ExpiresAt: expiresAt},
+			},
+			wantBudgets: map[string]decimal.Decimal{
+				"1": decimal.NewFromFloat(100),
+				"2": decimal.NewFromFloat(1),
+			},
+			wantActive: map[string]bool{"1": true, "2": false},
+			wantErr:    pkg.Errorf(pkg.ECONFLICT, "campaign with ID 2 can no longer be delivered"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			repo.campaigns = tt.campaigns
+
+			err := repo.DeliverCampaigns(context.Background(), tt.deliveries)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			for id, budget := range tt.wantBudgets {
+				assert.True(t, budget.Equal(repo.campaigns[id].Budget),
+					"budget of %s is %s, want %s", id, repo.campaigns[id].Budget, budget)
+				assert.Equal(t, tt.wantActive[id], repo.campaigns[id].Active)
+				// deliveries are only counted once confirmed
+				assert.Equal(t, model.DeliveryStats{}, repo.stats[id])
+			}
+
+			var reserved []string
+			for id := range repo.reservations {
+				reserved = append(reserved, id)
+			}
+			slices.Sort(reserved)
+			assert.Equal(t, tt.wantReserved, reserved)
+			for id, cost := range tt.wantCosts {
+				assert.True(t, cost.Equal(repo.reservations[id].Cost),
+					"cost of %s is %s, want %s", id, repo.reservations[id].Cost, cost)
+			}
+		})
+	}
+}
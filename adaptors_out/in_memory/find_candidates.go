@@ -0,0 +1,81 @@
+package in_memory
+
+import (
+	"context"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+)
+
+// FindCandidates returns the campaigns of the targeting lookups matching the targeting,
+// including the campaigns leaving some of their targeting fields empty, with their creatives,
+// their delivery stats and the stats of their targeting. When formats or sizes are given,
+// only the campaigns allowing a slot of one of the formats, banner when none is given, are
+// returned, the banner campaigns also allowing one of the sizes.
+func (r *CampaignRepository) FindCandidates(ctx context.Context, targeting model.Targeting,
+	formats []model.CreativeFormat, sizes []model.Size) ([]model.Candidate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	allowed := r.findSlotCampaigns(formats, sizes)
+
+	var candidates []model.Candidate
+	for _, t := range targeting.Generalizations() {
+		for _, b := range r.campaignsLookup[t.Country][t.Device][t.OS] {
+			if allowed != nil && !allowed[b.ID] {
+				continue
+			}
+			campaign := r.campaigns[b.ID]
+			candidates = append(candidates, model.Candidate{
+				Campaign:       campaign,
+				Stats:          r.stats[b.ID],
+				TargetingStats: r.targetingStats[campaign.Targeting()],
+				Creatives:      r.creatives[b.ID],
+			})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, pkg.Errorf(pkg.ENOTFOUND, "no campaign found for %s, %s, %s",
+			targeting.Country, targeting.Device, targeting.OS)
+	}
+	return candidates, nil
+}
+
+// findSlotCampaigns returns the IDs of the campaigns allowing the slot from the slots lookup,
+// or nil when the slot accepts any format and size.
+func (r *CampaignRepository) findSlotCampaigns(formats []model.CreativeFormat, sizes []model.Size) map[string]bool {
+	if len(formats) == 0 && len(sizes) == 0 {
+		return nil
+	}
+	if len(formats) == 0 {
+		formats = []model.CreativeFormat{model.Banner}
+	}
+
+	allowed := map[string]bool{}
+	for _, format := range formats {
+		for _, key := range []model.CreativeFormat{format, ""} {
+			for id := range r.slotsLookup.Formats[key] {
+				if format == model.Banner && !r.allowsSizes(id, sizes) {
+					continue
+				}
+				allowed[id] = true
+			}
+		}
+	}
+	return allowed
+}
+
+// allowsSizes tells whether the campaign allows a banner of one of the sizes, any size
+// allowing it when none is given.
+func (r *CampaignRepository) allowsSizes(campaignID string, sizes []model.Size) bool {
+	if len(sizes) == 0 || r.slotsLookup.Sizes[model.Size{}][campaignID] {
+		return true
+	}
+	for _, size := range sizes {
+		if r.slotsLookup.Sizes[size][campaignID] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_FindCandidates(t *testing.T) {
+	targeting := model.Targeting{Country: model.France, Device: model.Mobile, OS: model.Android}
+
+	tests := []struct {
+		name           string
+		setup          func(*CampaignRepository)
+		targeting      model.Targeting
+		formats        []model.CreativeFormat
+		sizes          []model.Size
+		wantCandidates []model.Candidate
+		wantErr        error
+	}{
+		{
+			name: "returns the campaigns in bid order with their creatives and stats",
+			setup: func(r *CampaignRepository) {
+				r.campaigns = model.Campaigns{
+					"1": {ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+						Active: false, Bid: decimal.NewFromFloat(100)},
+					"2": {ID: "2", Country: model.France, Device: model.Mobile, OS: model.Android,
+						Active: true, Bid: decimal.NewFromFloat(5)},
+				}
+				r.campaignsLookup = model.CampaignsLookup{
+					model.France: {model.Mobile: {model.Android: {
+						{ID: "1", Bid: decimal.NewFromFloat(100)},
+						{ID: "2", Bid: decimal.NewFromFloat(5)},
+					}}},
+				}
+				r.stats["2"] = model.DeliveryStats{Deliveries: 10, Clicks: 1}
+				r.targetingStats[targeting] = model.DeliveryStats{Deliveries: 30, Clicks: 2}
+				r.creatives["2"] = []model.Creative{{ID: "banner", CampaignID: "2", HTML: "<div>ad</div>"}}
+			},
+			targeting: targeting,
+			wantCandidates: []model.Candidate{
+				{
+					Campaign: model.Campaign{ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+						Active: false, Bid: decimal.NewFromFloat(100)},
+					TargetingStats: model.DeliveryStats{Deliveries: 30, Clicks: 2},
+				},
+				{
+					Campaign: model.Campaign{ID: "2", Country: model.France, Device: model.Mobile, OS: model.Android,
+						Active: true, Bid: decimal.NewFromFloat(5)},
+					Stats:          model.DeliveryStats{Deliveries: 10, Clicks: 1},
+					TargetingStats: model.DeliveryStats{Deliveries: 30, Clicks: 2},
+					Creatives:      []model.Creative{{ID: "banner", CampaignID: "2", HTML: "<div>ad</div>"}},
+				},
+			},
+		},
+		{
+			name: "includes the campaigns matching any value of their empty targeting fields",
+			setup: func(r *CampaignRepository) {
+				r.campaigns = model.Campaigns{
+					"1": {ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android},
+					"2": {ID: "2", Country: model.France},
+					"3": {ID: "3", OS: model.Android},
+					"4": {ID: "4", Country: model.Spain},
+				}
+				r.campaignsLookup = model.CampaignsLookup{
+					model.France: {
+						model.Mobile: {model.Android: {{ID: "1"}}},
+						"":           {"": {{ID: "2"}}},
+					},
+					"":          {"": {model.Android: {{ID: "3"}}}},
+					model.Spain: {"": {"": {{ID: "4"}}}},
+				}
+				r.targetingStats[model.Targeting{Country: model.France}] = model.DeliveryStats{Deliveries: 7}
+			},
+			targeting: targeting,
+			wantCandidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android}},
+				{
+					Campaign:       model.Campaign{ID: "2", Country: model.France},
+					TargetingStats: model.DeliveryStats{Deliveries: 7},
+				},
+				{Campaign: model.Campaign{ID: "3", OS: model.Android}},
+			},
+		},
+		{
+			name: "returns the campaigns once when the targeting has empty fields",
+			setup: func(r *CampaignRepository) {
+				r.campaigns = model.Campaigns{
+					"1": {ID: "1", Country: model.France},
+					"2": {ID: "2"},
+				}
+				r.campaignsLookup = model.CampaignsLookup{
+					model.France: {"": {"": {{ID: "1"}}}},
+					"":           {"": {"": {{ID: "2"}}}},
+				}
+			},
+			targeting: model.Targeting{Country: model.France, OS: model.Android},
+			wantCandidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "1", Country: model.France}},
+				{Campaign: model.Campaign{ID: "2"}},
+			},
+		},
+		{
+			name: "filters the campaigns by the formats and banner sizes of the slot",
+			setup: func(r *CampaignRepository) {
+				for _, c := range []model.Campaign{
+					{ID: "any", Bid: decimal.NewFromFloat(5)},
+					{ID: "leaderboard", Bid: decimal.NewFromFloat(4), Formats: []model.CreativeFormat{model.Banner},
+						Sizes: []model.Size{{Width: 728, Height: 90}}},
+					{ID: "rectangle", Bid: decimal.NewFromFloat(3), Sizes: []model.Size{{Width: 300, Height: 250}}},
+					{ID: "native", Bid: decimal.NewFromFloat(2), Formats: []model.CreativeFormat{model.Native}},
+					{ID: "video", Bid: decimal.NewFromFloat(1), Formats: []model.CreativeFormat{model.Video}},
+				} {
+					assert.NoError(t, r.CreateCampaign(context.Background(), c))
+				}
+			},
+			targeting: targeting,
+			formats:   []model.CreativeFormat{model.Banner, model.Native},
+			sizes:     []model.Size{{Width: 300, Height: 250}},
+			wantCandidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "any", Bid: decimal.NewFromFloat(5)}},
+				{Campaign: model.Campaign{ID: "rectangle", Bid: decimal.NewFromFloat(3),
+					Sizes: []model.Size{{Width: 300, Height: 250}}}},
+				{Campaign: model.Campaign{ID: "native", Bid: decimal.NewFromFloat(2),
+					Formats: []model.CreativeFormat{model.Native}}},
+			},
+		},
+		{
+			name: "sizes without formats only fit the banner campaigns",
+			setup: func(r *CampaignRepository) {
+				for _, c := range []model.Campaign{
+					{ID: "rectangle", Bid: decimal.NewFromFloat(3), Sizes: []model.Size{{Width: 300, Height: 250}}},
+					{ID: "native", Bid: decimal.NewFromFloat(2), Formats: []model.CreativeFormat{model.Native}},
+				} {
+					assert.NoError(t, r.CreateCampaign(context.Background(), c))
+				}
+			},
+			targeting: targeting,
+			sizes:     []model.Size{{Width: 728, Height: 90}},
+			wantErr:   pkg.Errorf(pkg.ENOTFOUND, "no campaign found for FR, mobile, android"),
+		},
+		{
+			name:      "no campaign found",
+			setup:     func(r *CampaignRepository) {},
+			targeting: targeting,
+			wantErr:   pkg.Errorf(pkg.ENOTFOUND, "no campaign found for FR, mobile, android"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			tt.setup(repo)
+
+			candidates, err := repo.FindCandidates(context.Background(), tt.targeting, tt.formats, tt.sizes)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCandidates, candidates)
+		})
+	}
+}
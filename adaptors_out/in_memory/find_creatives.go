@@ -0,0 +1,20 @@
+package in_memory
+
+import (
+	"context"
+	"slices"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+)
+
+// FindCreatives returns the creatives of the campaign in creation order.
+func (r *CampaignRepository) FindCreatives(ctx context.Context, campaignID string) ([]model.Creative, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.campaigns[campaignID]; !ok {
+		return nil, pkg.Errorf(pkg.ENOTFOUND, "campaign with ID %s not found", campaignID)
+	}
+	return slices.Clone(r.creatives[campaignID]), nil
+}
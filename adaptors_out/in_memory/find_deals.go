@@ -0,0 +1,21 @@
+package in_memory
+
+import (
+	"context"
+
+	"ad-campaign-delivery/model"
+)
+
+// FindDeals returns the deals with the given IDs in the same order, skipping unknown IDs.
+func (r *CampaignRepository) FindDeals(ctx context.Context, ids []string) ([]model.Deal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deals := make([]model.Deal, 0, len(ids))
+	for _, id := range ids {
+		if deal, ok := r.deals[id]; ok {
+			deals = append(deals, deal)
+		}
+	}
+	return deals, nil
+}
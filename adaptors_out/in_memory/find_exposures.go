@@ -0,0 +1,36 @@
+package in_memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"ad-campaign-delivery/model"
+)
+
+// FindExposures returns the delivery history of the user within the ttl,
+// empty when the user was never delivered a campaign.
+func (r *ExposureRepository) FindExposures(ctx context.Context, userID string) (model.Exposures, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	since := time.Now().Add(-r.ttl)
+	exposures := model.Exposures{}
+	for campaignID, times := range r.exposures[userID] {
+		if unexpired := unexpiredTimes(times, since); len(unexpired) > 0 {
+			exposures[campaignID] = slices.Clone(unexpired)
+		}
+	}
+	return exposures, nil
+}
+
+// unexpiredTimes returns the times, sorted from the oldest, that are after since.
+func unexpiredTimes(times []time.Time, since time.Time) []time.Time {
+	i, _ := slices.BinarySearchFunc(times, since, func(at, since time.Time) int {
+		if at.After(since) {
+			return 1
+		}
+		return -1
+	})
+	return times[i:]
+}
@@ -0,0 +1,56 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExposureRepository_FindExposures(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		setup         func(*ExposureRepository)
+		userID        string
+		wantExposures model.Exposures
+	}{
+		{
+			name:          "user never delivered",
+			setup:         func(r *ExposureRepository) {},
+			userID:        "user1",
+			wantExposures: model.Exposures{},
+		},
+		{
+			name: "returns the deliveries of the user within the ttl",
+			setup: func(r *ExposureRepository) {
+				r.exposures["user1"] = model.Exposures{
+					"camp1": {now.Add(-48 * time.Hour), now.Add(-2 * time.Hour), now.Add(-time.Hour)},
+					"camp2": {now.Add(-30 * time.Hour)},
+				}
+				r.exposures["user2"] = model.Exposures{
+					"camp1": {now.Add(-time.Minute)},
+				}
+			},
+			userID: "user1",
+			wantExposures: model.Exposures{
+				"camp1": {now.Add(-2 * time.Hour), now.Add(-time.Hour)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewExposureRepository(24*time.Hour, 30*time.Minute)
+			tt.setup(repo)
+
+			exposures, err := repo.FindExposures(context.Background(), tt.userID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExposures, exposures)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package in_memory
+
+import (
+	"context"
+
+	"ad-campaign-delivery/model"
+	"github.com/shopspring/decimal"
+)
+
+// FindFloor returns the floor of the most specific floor rule matching the targeting.
+func (r *CampaignRepository) FindFloor(ctx context.Context, targeting model.Targeting) (decimal.Decimal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.floorRules.Floor(targeting), nil
+}
@@ -0,0 +1,22 @@
+package in_memory
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"ad-campaign-delivery/model"
+)
+
+// FindPageView returns the categories delivered in the page view,
+// empty when it delivered nothing within the page view ttl.
+func (r *ExposureRepository) FindPageView(ctx context.Context, pageViewID string) (model.PageView, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	view, ok := r.pageViews[pageViewID]
+	if !ok || !view.deliveredAt.After(time.Now().Add(-r.pageViewTTL)) {
+		return model.PageView{}, nil
+	}
+	return maps.Clone(view.categories), nil
+}
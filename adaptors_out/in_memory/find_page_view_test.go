@@ -0,0 +1,58 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExposureRepository_FindPageView(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		setup        func(*ExposureRepository)
+		wantPageView model.PageView
+	}{
+		{
+			name:         "page view without delivery",
+			setup:        func(r *ExposureRepository) {},
+			wantPageView: model.PageView{},
+		},
+		{
+			name: "returns the categories delivered in the page view",
+			setup: func(r *ExposureRepository) {
+				r.pageViews["view1"] = pageView{
+					categories:  model.PageView{"automotive": "acme"},
+					deliveredAt: now.Add(-time.Minute),
+				}
+			},
+			wantPageView: model.PageView{"automotive": "acme"},
+		},
+		{
+			name: "page view expired",
+			setup: func(r *ExposureRepository) {
+				r.pageViews["view1"] = pageView{
+					categories:  model.PageView{"automotive": "acme"},
+					deliveredAt: now.Add(-time.Hour),
+				}
+			},
+			wantPageView: model.PageView{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewExposureRepository(24*time.Hour, 30*time.Minute)
+			tt.setup(repo)
+
+			view, err := repo.FindPageView(context.Background(), "view1")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPageView, view)
+		})
+	}
+}
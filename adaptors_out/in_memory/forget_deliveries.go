@@ -0,0 +1,41 @@
+package in_memory
+
+import (
+	"context"
+	"slices"
+
+	"ad-campaign-delivery/model"
+)
+
+// ForgetDeliveries drops the released deliveries from the history of their user and their page view,
+// so they no longer count against the caps, sequences and competitive separation. The category of the
+// page view is dropped when it was delivered for the advertiser of the delivery, even if another
+// delivery of that advertiser in the page view is still pending.
+func (r *ExposureRepository) ForgetDeliveries(ctx context.Context, deliveries []model.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range deliveries {
+		if exposures, ok := r.exposures[d.UserID]; ok && d.UserID != "" {
+			times := exposures[d.CampaignID]
+			if i := slices.IndexFunc(times, d.ReservedAt.Equal); i >= 0 {
+				times = slices.Delete(times, i, i+1)
+			}
+			if len(times) == 0 {
+				delete(exposures, d.CampaignID)
+			} else {
+				exposures[d.CampaignID] = times
+			}
+			if len(exposures) == 0 {
+				delete(r.exposures, d.UserID)
+			}
+		}
+
+		if view, ok := r.pageViews[d.PageViewID]; ok && d.PageViewID != "" && d.Category != "" {
+			if advertiser, ok := view.categories[d.Category]; ok && advertiser == d.Advertiser {
+				delete(view.categories, d.Category)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExposureRepository_ForgetDeliveries(t *testing.T) {
+	now := time.Now()
+	released := model.Delivery{ReservationID: "r2", CampaignID: "camp1", UserID: "user1",
+		PageViewID: "view1", Category: "automotive", Advertiser: "acme", ReservedAt: now}
+
+	tests := []struct {
+		name          string
+		delivery      model.Delivery
+		wantExposures model.Exposures
+		wantPageView  model.PageView
+	}{
+		{
+			name:          "forgets the released delivery of the user and the page view",
+			delivery:      released,
+			wantExposures: model.Exposures{"camp1": {now.Add(-time.Hour)}},
+			wantPageView:  model.PageView{"travel": "initech"},
+		},
+		{
+			name: "keeps the category delivered for another advertiser",
+			delivery: model.Delivery{ReservationID: "r2", CampaignID: "camp1", UserID: "user1",
+				PageViewID: "view1", Category: "automotive", Advertiser: "globex", ReservedAt: now},
+			wantExposures: model.Exposures{"camp1": {now.Add(-time.Hour)}},
+			wantPageView:  model.PageView{"automotive": "acme", "travel": "initech"},
+		},
+		{
+			name: "delivery without user nor page view",
+			delivery: model.Delivery{ReservationID: "r2", CampaignID: "camp1",
+				Category: "automotive", Advertiser: "acme", ReservedAt: now},
+			wantExposures: model.Exposures{"camp1": {now.Add(-time.Hour), now}},
+			wantPageView:  model.PageView{"automotive": "acme", "travel": "initech"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewExposureRepository(24*time.Hour, 30*time.Minute)
+			ctx := context.Background()
+			assert.NoError(t, repo.RecordExposures(ctx, "user1", []string{"camp1"}, now.Add(-time.Hour)))
+			assert.NoError(t, repo.RecordExposures(ctx, "user1", []string{"camp1"}, now))
+			assert.NoError(t, repo.RecordPageView(ctx, "view1",
+				model.PageView{"automotive": "acme", "travel": "initech"}, now))
+
+			err := repo.ForgetDeliveries(ctx, []model.Delivery{tt.delivery})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExposures, repo.exposures["user1"])
+			assert.Equal(t, tt.wantPageView, repo.pageViews["view1"].categories)
+		})
+	}
+
+	t.Run("released reservation does not count toward the frequency cap", func(t *testing.T) {
+		repo := NewExposureRepository(24*time.Hour, 30*time.Minute)
+		ctx := context.Background()
+		capped := model.FrequencyCap{Impressions: 1, Period: time.Hour}
+		assert.NoError(t, repo.RecordExposures(ctx, "user1", []string{"camp1"}, now))
+		exposures, err := repo.FindExposures(ctx, "user1")
+		assert.NoError(t, err)
+		assert.True(t, capped.Reached("camp1", exposures, now))
+
+		assert.NoError(t, repo.ForgetDeliveries(ctx, []model.Delivery{released}))
+
+		exposures, err = repo.FindExposures(ctx, "user1")
+		assert.NoError(t, err)
+		assert.False(t, capped.Reached("camp1", exposures, now))
+	})
+}
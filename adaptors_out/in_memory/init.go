@@ -2,6 +2,7 @@ package in_memory
 
 import (
 	"sync"
+	"time"
 
 	"ad-campaign-delivery/model"
 	"ad-campaign-delivery/ports_out"
@@ -11,7 +12,15 @@ import (
 type CampaignRepository struct {
 	ports_out.CampaignRepository
 	campaignsLookup model.CampaignsLookup
+	slotsLookup     model.SlotsLookup
 	campaigns       model.Campaigns
+	floorRules      model.FloorRules
+	deals           model.Deals
+	creatives       model.Creatives
+	reservations    map[string]model.Delivery
+	trackedEvents   map[trackedEvent]time.Time
+	stats           map[string]model.DeliveryStats
+	targetingStats  map[model.Targeting]model.DeliveryStats
 	mu              sync.RWMutex
 	log             *zerolog.Logger
 }
@@ -19,8 +28,52 @@ type CampaignRepository struct {
 func NewCampaignRepository(log *zerolog.Logger) *CampaignRepository {
 	return &CampaignRepository{
 		campaignsLookup: model.CampaignsLookup{},
-		campaigns:       model.Campaigns{},
-		log:             log,
+		slotsLookup: model.SlotsLookup{
+			Formats: map[model.CreativeFormat]map[string]bool{},
+			Sizes:   map[model.Size]map[string]bool{},
+		},
+		campaigns:      model.Campaigns{},
+		floorRules:     model.FloorRules{},
+		deals:          model.Deals{},
+		creatives:      model.Creatives{},
+		reservations:   map[string]model.Delivery{},
+		trackedEvents:  map[trackedEvent]time.Time{},
+		stats:          map[string]model.DeliveryStats{},
+		targetingStats: map[model.Targeting]model.DeliveryStats{},
+		log:            log,
 	}
 
 }
+
+// trackedEvent is an event of a delivery already tracked, kept until its tracking expires
+// so repeated pings are ignored. Impressions are tracked until the reservation expires.
+type trackedEvent struct {
+	reservationID string
+	event         model.EventType
+}
+
+// ExposureRepository keeps the delivery history of the users for ttl, the longest period
+// the history is needed for, and the categories delivered in page views for pageViewTTL.
+type ExposureRepository struct {
+	ports_out.ExposureRepository
+	exposures   map[string]model.Exposures
+	pageViews   map[string]pageView
+	ttl         time.Duration
+	pageViewTTL time.Duration
+	mu          sync.RWMutex
+}
+
+// pageView holds the categories delivered in a page view and when it last delivered.
+type pageView struct {
+	categories  model.PageView
+	deliveredAt time.Time
+}
+
+func NewExposureRepository(ttl, pageViewTTL time.Duration) *ExposureRepository {
+	return &ExposureRepository{
+		exposures:   map[string]model.Exposures{},
+		pageViews:   map[string]pageView{},
+		ttl:         ttl,
+		pageViewTTL: pageViewTTL,
+	}
+}
@@ -0,0 +1,35 @@
+package in_memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"ad-campaign-delivery/model"
+)
+
+// RecordExposures adds the delivery of the campaigns at the given time to the history
+// of the user, dropping the deliveries of these campaigns older than the ttl.
+func (r *ExposureRepository) RecordExposures(ctx context.Context, userID string, campaignIDs []string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	exposures, ok := r.exposures[userID]
+	if !ok {
+		exposures = model.Exposures{}
+		r.exposures[userID] = exposures
+	}
+
+	since := time.Now().Add(-r.ttl)
+	for _, campaignID := range campaignIDs {
+		times := unexpiredTimes(exposures[campaignID], since)
+		i, _ := slices.BinarySearchFunc(times, at, func(t, at time.Time) int {
+			if t.After(at) {
+				return 1
+			}
+			return -1
+		})
+		exposures[campaignID] = slices.Insert(slices.Clip(times), i, at)
+	}
+	return nil
+}
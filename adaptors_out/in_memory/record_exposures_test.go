@@ -0,0 +1,60 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExposureRepository_RecordExposures(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		setup         func(*ExposureRepository)
+		campaignIDs   []string
+		at            time.Time
+		wantExposures model.Exposures
+	}{
+		{
+			name:        "first deliveries of the user",
+			setup:       func(r *ExposureRepository) {},
+			campaignIDs: []string{"camp1", "camp2"},
+			at:          now,
+			wantExposures: model.Exposures{
+				"camp1": {now},
+				"camp2": {now},
+			},
+		},
+		{
+			name: "keeps the history sorted and drops the expired deliveries",
+			setup: func(r *ExposureRepository) {
+				r.exposures["user1"] = model.Exposures{
+					"camp1": {now.Add(-48 * time.Hour), now.Add(-2 * time.Hour), now},
+					"camp2": {now.Add(-48 * time.Hour)},
+				}
+			},
+			campaignIDs: []string{"camp1"},
+			at:          now.Add(-time.Hour),
+			wantExposures: model.Exposures{
+				"camp1": {now.Add(-2 * time.Hour), now.Add(-time.Hour), now},
+				"camp2": {now.Add(-48 * time.Hour)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewExposureRepository(24*time.Hour, 30*time.Minute)
+			tt.setup(repo)
+
+			err := repo.RecordExposures(context.Background(), "user1", tt.campaignIDs, tt.at)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExposures, repo.exposures["user1"])
+		})
+	}
+}
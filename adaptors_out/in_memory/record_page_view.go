@@ -0,0 +1,29 @@
+package in_memory
+
+import (
+	"context"
+	"time"
+
+	"ad-campaign-delivery/model"
+)
+
+// RecordPageView adds the categories delivered at the given time to the page view,
+// keeping the advertiser first delivered for each category.
+func (r *ExposureRepository) RecordPageView(ctx context.Context, pageViewID string, categories model.PageView, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	view, ok := r.pageViews[pageViewID]
+	if !ok || !view.deliveredAt.After(time.Now().Add(-r.pageViewTTL)) {
+		view = pageView{categories: model.PageView{}}
+	}
+
+	for category, advertiser := range categories {
+		if _, ok := view.categories[category]; !ok {
+			view.categories[category] = advertiser
+		}
+	}
+	view.deliveredAt = at
+	r.pageViews[pageViewID] = view
+	return nil
+}
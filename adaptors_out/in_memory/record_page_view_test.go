@@ -0,0 +1,63 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExposureRepository_RecordPageView(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		setup        func(*ExposureRepository)
+		categories   model.PageView
+		wantPageView model.PageView
+	}{
+		{
+			name:         "first delivery of the page view",
+			setup:        func(r *ExposureRepository) {},
+			categories:   model.PageView{"automotive": "acme"},
+			wantPageView: model.PageView{"automotive": "acme"},
+		},
+		{
+			name: "keeps the advertiser first delivered for each category",
+			setup: func(r *ExposureRepository) {
+				r.pageViews["view1"] = pageView{
+					categories:  model.PageView{"automotive": "acme"},
+					deliveredAt: now.Add(-time.Minute),
+				}
+			},
+			categories:   model.PageView{"automotive": "globex", "travel": "initech"},
+			wantPageView: model.PageView{"automotive": "acme", "travel": "initech"},
+		},
+		{
+			name: "restarts the expired page view",
+			setup: func(r *ExposureRepository) {
+				r.pageViews["view1"] = pageView{
+					categories:  model.PageView{"automotive": "acme"},
+					deliveredAt: now.Add(-time.Hour),
+				}
+			},
+			categories:   model.PageView{"travel": "initech"},
+			wantPageView: model.PageView{"travel": "initech"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewExposureRepository(24*time.Hour, 30*time.Minute)
+			tt.setup(repo)
+
+			err := repo.RecordPageView(context.Background(), "view1", tt.categories, now)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPageView, repo.pageViews["view1"].categories)
+			assert.Equal(t, now, repo.pageViews["view1"].deliveredAt)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package in_memory
+
+import (
+	"context"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"github.com/shopspring/decimal"
+)
+
+// ReleaseDelivery releases the reserved delivery on the loss notice of the upstream auction, refunding
+// its cost to the campaign budget, and returns it. Loss notices of confirmed deliveries are ignored,
+// returning no delivery, and unknown, expired or already released reservations are not found.
+func (r *CampaignRepository) ReleaseDelivery(ctx context.Context, reservationID string) (model.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.confirmed(reservationID) {
+		return model.Delivery{}, nil
+	}
+
+	d, err := r.pendingReservation(reservationID)
+	if err != nil {
+		return model.Delivery{}, err
+	}
+
+	r.release(d, time.Now())
+	return d, nil
+}
+
+// release forgets the reservation, refunding its cost to the campaign budget.
+// It must be called with the write lock held.
+func (r *CampaignRepository) release(d model.Delivery, now time.Time) {
+	delete(r.reservations, d.ReservationID)
+	r.refund(d.CampaignID, d.Cost, now)
+}
+
+// refund adds the amount back to the campaign budget, reactivating the unexpired campaign it affords
+// again. It must be called with the write lock held.
+func (r *CampaignRepository) refund(campaignID string, amount decimal.Decimal, now time.Time) {
+	campaign, ok := r.campaigns[campaignID]
+	if !ok {
+		return
+	}
+	outOfBudget := campaign.UnitCost().GreaterThan(campaign.Budget)
+	campaign.Budget = campaign.Budget.Add(amount)
+
+	unexpired := campaign.ExpiresAt.IsZero() || campaign.ExpiresAt.After(now)
+	if outOfBudget && unexpired && !campaign.UnitCost().GreaterThan(campaign.Budget) {
+		campaign.Active = true
+	}
+	r.campaigns[campaignID] = campaign
+}
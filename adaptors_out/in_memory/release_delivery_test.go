@@ -0,0 +1,94 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_ReleaseDelivery(t *testing.T) {
+	expiresAt := time.Now().Add(5 * time.Minute)
+
+	tests := []struct {
+		name          string
+		campaign      model.Campaign
+		confirmed     bool
+		reservationID string
+		wantBudget    decimal.Decimal
+		wantActive    bool
+		wantErr       error
+	}{
+		{
+			name: "refunds the cost of the lost delivery",
+			campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(95)},
+			reservationID: "r1",
+			wantBudget:    decimal.NewFromFloat(100),
+			wantActive:    true,
+		},
+		{
+			name: "reactivates the campaign the refund affords again",
+			campaign: model.Campaign{ID: "1", Active: false, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(2)},
+			reservationID: "r1",
+			wantBudget:    decimal.NewFromFloat(7),
+			wantActive:    true,
+		},
+		{
+			name: "loss of a confirmed delivery is ignored",
+			campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(95)},
+			confirmed:     true,
+			reservationID: "r1",
+			wantBudget:    decimal.NewFromFloat(95),
+			wantActive:    true,
+		},
+		{
+			name: "unknown reservation",
+			campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(95)},
+			reservationID: "r2",
+			wantBudget:    decimal.NewFromFloat(95),
+			wantActive:    true,
+			wantErr:       pkg.Errorf(pkg.ENOTFOUND, "reservation with ID r2 not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			repo.campaigns[tt.campaign.ID] = tt.campaign
+			repo.reservations["r1"] = model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: expiresAt}
+			if tt.confirmed {
+				assert.NoError(t, repo.ConfirmDelivery(context.Background(), "r1"))
+			}
+
+			delivery, err := repo.ReleaseDelivery(context.Background(), tt.reservationID)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.NotContains(t, repo.reservations, tt.reservationID)
+			}
+			if tt.wantErr == nil && !tt.confirmed {
+				assert.Equal(t, "r1", delivery.ReservationID)
+			} else {
+				assert.Equal(t, model.Delivery{}, delivery)
+			}
+			campaign := repo.campaigns[tt.campaign.ID]
+			assert.True(t, tt.wantBudget.Equal(campaign.Budget),
+				"budget is %s, want %s", campaign.Budget, tt.wantBudget)
+			assert.Equal(t, tt.wantActive, campaign.Active)
+		})
+	}
+}
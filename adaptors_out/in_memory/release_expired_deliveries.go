@@ -0,0 +1,31 @@
+package in_memory
+
+import (
+	"time"
+
+	"ad-campaign-delivery/model"
+)
+
+// ReleaseExpiredDeliveries releases the cost of the reserved deliveries whose impression did not
+// come in time back to the campaign budget, reactivating the unexpired campaigns it affords again,
+// forgets the tracked events whose tracking expired, and returns the released deliveries.
+func (r *CampaignRepository) ReleaseExpiredDeliveries() []model.Delivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var released []model.Delivery
+	for _, d := range r.reservations {
+		if !d.ExpiresAt.After(now) {
+			r.release(d, now)
+			released = append(released, d)
+		}
+	}
+
+	for key, expiresAt := range r.trackedEvents {
+		if !expiresAt.After(now) {
+			delete(r.trackedEvents, key)
+		}
+	}
+	return released
+}
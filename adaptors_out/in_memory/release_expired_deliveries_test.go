@@ -0,0 +1,108 @@
+package in_memory
+
+import (
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_ReleaseExpiredDeliveries(t *testing.T) {
+	now := time.Now()
+	expired := now.Add(-time.Second)
+
+	tests := []struct {
+		name         string
+		campaign     model.Campaign
+		reservation  model.Delivery
+		wantBudget   decimal.Decimal
+		wantActive   bool
+		wantReserved bool
+	}{
+		{
+			name: "releases the cost of the expired reservation",
+			campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(95)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: expired},
+			wantBudget: decimal.NewFromFloat(100),
+			wantActive: true,
+		},
+		{
+			name: "reactivates the campaign the released cost affords again",
+			campaign: model.Campaign{ID: "1", Active: false, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(2)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: expired},
+			wantBudget: decimal.NewFromFloat(7),
+			wantActive: true,
+		},
+		{
+			name: "expired campaign is not reactivated",
+			campaign: model.Campaign{ID: "1", Active: false, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(2), ExpiresAt: now.Add(-time.Hour)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: expired},
+			wantBudget: decimal.NewFromFloat(7),
+			wantActive: false,
+		},
+		{
+			name: "impression goal campaign is not reactivated",
+			campaign: model.Campaign{ID: "goal", Active: false, Bid: decimal.NewFromFloat(1),
+				Priority: model.Guaranteed, ImpressionGoal: 1},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "goal",
+				Cost: decimal.Zero, ExpiresAt: expired},
+			wantBudget: decimal.Zero,
+			wantActive: false,
+		},
+		{
+			name: "pending reservation is kept",
+			campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(95)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: now.Add(time.Minute)},
+			wantBudget:   decimal.NewFromFloat(95),
+			wantActive:   true,
+			wantReserved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			repo.campaigns[tt.campaign.ID] = tt.campaign
+			repo.reservations[tt.reservation.ReservationID] = tt.reservation
+
+			released := repo.ReleaseExpiredDeliveries()
+
+			if tt.wantReserved {
+				assert.Empty(t, released)
+			} else {
+				assert.Equal(t, []model.Delivery{tt.reservation}, released)
+			}
+			campaign := repo.campaigns[tt.campaign.ID]
+			assert.True(t, tt.wantBudget.Equal(campaign.Budget),
+				"budget is %s, want %s", campaign.Budget, tt.wantBudget)
+			assert.Equal(t, tt.wantActive, campaign.Active)
+			_, reserved := repo.reservations[tt.reservation.ReservationID]
+			assert.Equal(t, tt.wantReserved, reserved)
+		})
+	}
+
+	t.Run("forgets the expired tracked events", func(t *testing.T) {
+		l := logger.Init()
+		repo := NewCampaignRepository(&l)
+		repo.trackedEvents[trackedEvent{reservationID: "r1", event: model.Click}] = expired
+		repo.trackedEvents[trackedEvent{reservationID: "r2", event: model.Click}] = now.Add(time.Hour)
+
+		repo.ReleaseExpiredDeliveries()
+
+		assert.Equal(t, map[trackedEvent]time.Time{
+			{reservationID: "r2", event: model.Click}: now.Add(time.Hour),
+		}, repo.trackedEvents)
+	})
+}
@@ -0,0 +1,16 @@
+package in_memory
+
+import (
+	"context"
+
+	"ad-campaign-delivery/model"
+)
+
+// SaveDeal inserts the deal into the deal table, replacing the existing deal with the same ID.
+func (r *CampaignRepository) SaveDeal(ctx context.Context, deal model.Deal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deals[deal.ID] = deal
+	return nil
+}
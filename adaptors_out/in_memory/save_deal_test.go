@@ -0,0 +1,57 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_SaveAndFindDeals(t *testing.T) {
+	l := logger.Init()
+	repo := NewCampaignRepository(&l)
+
+	deals := []model.Deal{
+		{ID: "deal1", Price: decimal.NewFromFloat(2), Campaigns: []string{"1"}},
+		{ID: "deal2", Price: decimal.NewFromFloat(3), Campaigns: []string{"2"}, Publishers: []string{"pub1"}},
+		// replaces the previous deal with the same ID
+		{ID: "deal1", Price: decimal.NewFromFloat(2.5), Campaigns: []string{"1", "3"}},
+	}
+	for _, deal := range deals {
+		assert.NoError(t, repo.SaveDeal(context.Background(), deal))
+	}
+	assert.Len(t, repo.deals, 2)
+
+	tests := []struct {
+		name      string
+		ids       []string
+		wantDeals []model.Deal
+	}{
+		{
+			name:      "deals in the order of the IDs",
+			ids:       []string{"deal2", "deal1"},
+			wantDeals: []model.Deal{deals[1], deals[2]},
+		},
+		{
+			name:      "skips unknown deals",
+			ids:       []string{"unknown", "deal1"},
+			wantDeals: []model.Deal{deals[2]},
+		},
+		{
+			name:      "no known deal",
+			ids:       []string{"unknown"},
+			wantDeals: []model.Deal{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := repo.FindDeals(context.Background(), tt.ids)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDeals, found)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package in_memory
+
+import (
+	"context"
+
+	"ad-campaign-delivery/model"
+)
+
+// SaveFloorRule inserts the floor rule into the floor-rule table,
+// replacing the existing rule for the same targeting.
+func (r *CampaignRepository) SaveFloorRule(ctx context.Context, rule model.FloorRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.floorRules[rule.Targeting] = rule.Floor
+	return nil
+}
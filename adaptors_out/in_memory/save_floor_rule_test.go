@@ -0,0 +1,64 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_SaveAndFindFloorRule(t *testing.T) {
+	l := logger.Init()
+	repo := NewCampaignRepository(&l)
+
+	rules := []model.FloorRule{
+		{Floor: decimal.NewFromFloat(0.5)},
+		{Targeting: model.Targeting{Country: model.France}, Floor: decimal.NewFromFloat(1)},
+		{Targeting: model.Targeting{Country: model.France, OS: model.Android}, Floor: decimal.NewFromFloat(2)},
+		{Targeting: model.Targeting{Country: model.France, Device: model.Mobile}, Floor: decimal.NewFromFloat(3)},
+		// replaces the previous rule for the same targeting
+		{Targeting: model.Targeting{Country: model.France}, Floor: decimal.NewFromFloat(1.5)},
+	}
+	for _, rule := range rules {
+		assert.NoError(t, repo.SaveFloorRule(context.Background(), rule))
+	}
+	assert.Len(t, repo.floorRules, 4)
+
+	tests := []struct {
+		name      string
+		targeting model.Targeting
+		wantFloor decimal.Decimal
+	}{
+		{
+			name:      "country and device rule is more specific than country and os",
+			targeting: model.Targeting{Country: model.France, Device: model.Mobile, OS: model.Android},
+			wantFloor: decimal.NewFromFloat(3),
+		},
+		{
+			name:      "country and os rule",
+			targeting: model.Targeting{Country: model.France, Device: model.Desktop, OS: model.Android},
+			wantFloor: decimal.NewFromFloat(2),
+		},
+		{
+			name:      "replaced country rule",
+			targeting: model.Targeting{Country: model.France, Device: model.Desktop, OS: model.Mac},
+			wantFloor: decimal.NewFromFloat(1.5),
+		},
+		{
+			name:      "catch-all rule",
+			targeting: model.Targeting{Country: model.Spain, Device: model.Mobile, OS: model.Android},
+			wantFloor: decimal.NewFromFloat(0.5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			floor, err := repo.FindFloor(context.Background(), tt.targeting)
+			assert.NoError(t, err)
+			assert.True(t, tt.wantFloor.Equal(floor), "floor %s, want %s", floor, tt.wantFloor)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package in_memory
+
+import (
+	"context"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"github.com/shopspring/decimal"
+)
+
+// TrackEvent counts the click, conversion or video event of the tracked delivery in the campaign and targeting
+// stats and charges it at the clearing price of the delivery when the pricing model of the campaign bills it,
+// once per delivery: repeated events of the delivery are ignored until its tracking expires.
+// It returns the campaign of the delivery, with the click URL of the tracked creative when it has one.
+func (r *CampaignRepository) TrackEvent(ctx context.Context, tracking model.Tracking, event model.EventType) (model.Campaign, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	campaign, ok := r.campaigns[tracking.CampaignID]
+	if !ok {
+		return model.Campaign{}, pkg.Errorf(pkg.ENOTFOUND, "campaign with ID %s not found", tracking.CampaignID)
+	}
+
+	key := trackedEvent{reservationID: tracking.ReservationID, event: event}
+	if _, tracked := r.trackedEvents[key]; tracked {
+		return r.withCreativeClickURL(campaign, tracking), nil
+	}
+	r.trackedEvents[key] = tracking.ExpiresAt
+
+	r.applyEvent(campaign, event, tracking.ClearingPrice)
+	return r.withCreativeClickURL(r.campaigns[campaign.ID], tracking), nil
+}
+
+// withCreativeClickURL replaces the click URL of the campaign by the one of the tracked creative, if any.
+func (r *CampaignRepository) withCreativeClickURL(campaign model.Campaign, tracking model.Tracking) model.Campaign {
+	if creative, ok := r.findCreative(campaign.ID, tracking.CreativeID); ok && creative.ClickURL != "" {
+		campaign.ClickURL = creative.ClickURL
+	}
+	return campaign
+}
+
+// applyEvent counts the event in the campaign and targeting stats and deducts its cost at the
+// clearing price from the campaign budget, capped at the remaining budget like the deliveries.
+// It must be called with the write lock held.
+func (r *CampaignRepository) applyEvent(campaign model.Campaign, event model.EventType, clearingPrice decimal.Decimal) {
+	targeting := campaign.Targeting()
+	r.stats[campaign.ID] = countEvent(r.stats[campaign.ID], event)
+	r.targetingStats[targeting] = countEvent(r.targetingStats[targeting], event)
+
+	cost := campaign.EventCost(event, clearingPrice)
+	if campaign.Budgeted() {
+		cost = decimal.Min(cost, campaign.Budget)
+	}
+	if cost.IsPositive() {
+		r.deductBudget(campaign.ID, cost)
+	}
+}
+
+func countEvent(stats model.DeliveryStats, event model.EventType) model.DeliveryStats {
+	switch event {
+	case model.Click:
+		stats.Clicks++
+	case model.Conversion:
+		stats.Conversions++
+	case model.Start:
+		stats.Video.Starts++
+	case model.FirstQuartile:
+		stats.Video.FirstQuartiles++
+	case model.Midpoint:
+		stats.Video.Midpoints++
+	case model.ThirdQuartile:
+		stats.Video.ThirdQuartiles++
+	case model.Complete:
+		stats.Video.Completes++
+	}
+	return stats
+}
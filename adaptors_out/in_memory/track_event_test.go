@@ -0,0 +1,248 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_TrackEvent(t *testing.T) {
+	campaign := model.Campaign{ID: "1", PricingModel: model.CPC, Active: true, ClickURL: "https://example.com",
+		Bid: decimal.NewFromFloat(0.35), Budget: decimal.NewFromFloat(10)}
+	tracking := model.Tracking{ReservationID: "r1", CampaignID: "1", ClearingPrice: decimal.NewFromFloat(0.35),
+		ExpiresAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name       string
+		tracked    []model.EventType
+		tracking   model.Tracking
+		event      model.EventType
+		wantBudget decimal.Decimal
+		wantStats  model.DeliveryStats
+		// wantClickURL is the click URL of the campaign when empty
+		wantClickURL string
+		wantErr      error
+	}{
+		{
+			name:       "click charged to cpc campaign",
+			tracking:   tracking,
+			event:      model.Click,
+			wantBudget: decimal.NewFromFloat(9.65),
+			wantStats:  model.DeliveryStats{Clicks: 1},
+		},
+		{
+			name: "click charged at the clearing price of the delivery rather than the bid",
+			tracking: model.Tracking{ReservationID: "r1", CampaignID: "1", ClearingPrice: decimal.NewFromFloat(0.2),
+				ExpiresAt: tracking.ExpiresAt},
+			event:      model.Click,
+			wantBudget: decimal.NewFromFloat(9.8),
+			wantStats:  model.DeliveryStats{Clicks: 1},
+		},
+		{
+			name:       "repeated click of the delivery is ignored",
+			tracked:    []model.EventType{model.Click},
+			tracking:   tracking,
+			event:      model.Click,
+			wantBudget: decimal.NewFromFloat(9.65),
+			wantStats:  model.DeliveryStats{Clicks: 1},
+		},
+		{
+			name:       "conversion after the click of the delivery",
+			tracked:    []model.EventType{model.Click},
+			tracking:   tracking,
+			event:      model.Conversion,
+			wantBudget: decimal.NewFromFloat(9.65),
+			wantStats:  model.DeliveryStats{Clicks: 1, Conversions: 1},
+		},
+		{
+			name:       "click of another delivery",
+			tracked:    []model.EventType{model.Click},
+			tracking:   model.Tracking{ReservationID: "r2", CampaignID: "1", ClearingPrice: tracking.ClearingPrice, ExpiresAt: tracking.ExpiresAt},
+			event:      model.Click,
+			wantBudget: decimal.NewFromFloat(9.3),
+			wantStats:  model.DeliveryStats{Clicks: 2},
+		},
+		{
+			name: "click redirected to the click URL of the tracked creative",
+			tracking: model.Tracking{ReservationID: "r1", CampaignID: "1", CreativeID: "banner",
+				ClearingPrice: tracking.ClearingPrice, ExpiresAt: tracking.ExpiresAt},
+			event:        model.Click,
+			wantBudget:   decimal.NewFromFloat(9.65),
+			wantStats:    model.DeliveryStats{Clicks: 1},
+			wantClickURL: "https://example.com/banner",
+		},
+		{
+			name:    "repeated click redirected to the click URL of the tracked creative",
+			tracked: []model.EventType{model.Click},
+			tracking: model.Tracking{ReservationID: "r1", CampaignID: "1", CreativeID: "banner",
+				ClearingPrice: tracking.ClearingPrice, ExpiresAt: tracking.ExpiresAt},
+			event:        model.Click,
+			wantBudget:   decimal.NewFromFloat(9.65),
+			wantStats:    model.DeliveryStats{Clicks: 1},
+			wantClickURL: "https://example.com/banner",
+		},
+		{
+			name: "click of a creative without click URL",
+			tracking: model.Tracking{ReservationID: "r1", CampaignID: "1", CreativeID: "snippet",
+				ClearingPrice: tracking.ClearingPrice, ExpiresAt: tracking.ExpiresAt},
+			event:      model.Click,
+			wantBudget: decimal.NewFromFloat(9.65),
+			wantStats:  model.DeliveryStats{Clicks: 1},
+		},
+		{
+			name:       "unknown campaign",
+			tracking:   model.Tracking{ReservationID: "r1", CampaignID: "2", ClearingPrice: tracking.ClearingPrice, ExpiresAt: tracking.ExpiresAt},
+			event:      model.Click,
+			wantBudget: decimal.NewFromFloat(10),
+			wantErr:    pkg.Errorf(pkg.ENOTFOUND, "campaign with ID 2 not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			repo.campaigns[campaign.ID] = campaign
+			repo.creatives[campaign.ID] = []model.Creative{
+				{ID: "banner", CampaignID: "1", ClickURL: "https://example.com/banner"},
+				{ID: "snippet", CampaignID: "1"},
+			}
+			for _, event := range tt.tracked {
+				_, err := repo.TrackEvent(context.Background(), tracking, event)
+				assert.NoError(t, err)
+			}
+
+			got, err := repo.TrackEvent(context.Background(), tt.tracking, tt.event)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				wantClickURL := tt.wantClickURL
+				if wantClickURL == "" {
+					wantClickURL = campaign.ClickURL
+				}
+				assert.Equal(t, wantClickURL, got.ClickURL)
+			}
+			assert.True(t, tt.wantBudget.Equal(repo.campaigns[campaign.ID].Budget),
+				"budget is %s, want %s", repo.campaigns[campaign.ID].Budget, tt.wantBudget)
+			assert.Equal(t, tt.wantStats, repo.stats[campaign.ID])
+		})
+	}
+}
+
+func TestCampaignRepository_TrackEvent_PricingModels(t *testing.T) {
+	tests := []struct {
+		name       string
+		campaign   model.Campaign
+		event      model.EventType
+		wantBudget decimal.Decimal
+		wantActive bool
+		wantStats  model.DeliveryStats
+		wantErr    error
+	}{
+		{
+			name: "click charged to cpc campaign",
+			campaign: model.Campaign{ID: "1", PricingModel: model.CPC, Active: true,
+				Bid: decimal.NewFromFloat(0.35), Budget: decimal.NewFromFloat(10)},
+			event:      model.Click,
+			wantBudget: decimal.NewFromFloat(9.65),
+			wantActive: true,
+			wantStats:  model.DeliveryStats{Clicks: 1},
+		},
+		{
+			name: "conversion not charged to cpc campaign",
+			campaign: model.Campaign{ID: "1", PricingModel: model.CPC, Active: true,
+				Bid: decimal.NewFromFloat(0.35), Budget: decimal.NewFromFloat(10)},
+			event:      model.Conversion,
+			wantBudget: decimal.NewFromFloat(10),
+			wantActive: true,
+			wantStats:  model.DeliveryStats{Conversions: 1},
+		},
+		{
+			name: "conversion charged to cpa campaign, deactivated when budget is over",
+			campaign: model.Campaign{ID: "1", PricingModel: model.CPA, Active: true,
+				Bid: decimal.NewFromFloat(4), Budget: decimal.NewFromFloat(7)},
+			event:      model.Conversion,
+			wantBudget: decimal.NewFromFloat(3),
+			wantActive: false,
+			wantStats:  model.DeliveryStats{Conversions: 1},
+		},
+		{
+			name: "click not charged to cpm campaign",
+			campaign: model.Campaign{ID: "1", PricingModel: model.CPM, Active: true,
+				Bid: decimal.NewFromFloat(2), Budget: decimal.NewFromFloat(10)},
+			event:      model.Click,
+			wantBudget: decimal.NewFromFloat(10),
+			wantActive: true,
+			wantStats:  model.DeliveryStats{Clicks: 1},
+		},
+		{
+			name: "video event only counted",
+			campaign: model.Campaign{ID: "1", PricingModel: model.CPM, Active: true,
+				Bid: decimal.NewFromFloat(2), Budget: decimal.NewFromFloat(10)},
+			event:      model.Midpoint,
+			wantBudget: decimal.NewFromFloat(10),
+			wantActive: true,
+			wantStats:  model.DeliveryStats{Video: model.VideoStats{Midpoints: 1}},
+		},
+		{
+			name:     "campaign not found",
+			campaign: model.Campaign{ID: "2"},
+			event:    model.Click,
+			wantErr:  pkg.Errorf(pkg.ENOTFOUND, "campaign with ID 1 not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			repo.campaigns[tt.campaign.ID] = tt.campaign
+
+			tracking := model.Tracking{ReservationID: "r1", CampaignID: "1", ClearingPrice: tt.campaign.Bid,
+				ExpiresAt: time.Now().Add(time.Hour)}
+
+			_, err := repo.TrackEvent(context.Background(), tracking, tt.event)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.wantBudget.Equal(repo.campaigns["1"].Budget),
+				"budget %s, want %s", repo.campaigns["1"].Budget, tt.wantBudget)
+			assert.Equal(t, tt.wantActive, repo.campaigns["1"].Active)
+			assert.Equal(t, tt.wantStats, repo.stats["1"])
+			assert.Equal(t, tt.wantStats, repo.targetingStats[tt.campaign.Targeting()])
+		})
+	}
+}
+
+func TestCampaignRepository_TrackEvent_CappedAtTheBudget(t *testing.T) {
+	l := logger.Init()
+	repo := NewCampaignRepository(&l)
+	repo.campaigns["1"] = model.Campaign{ID: "1", PricingModel: model.CPC, Active: true,
+		Bid: decimal.NewFromFloat(4), Budget: decimal.NewFromFloat(10)}
+
+	// clicks of deliveries made before the budget was spent keep arriving
+	for _, reservationID := range []string{"r1", "r2", "r3", "r4"} {
+		tracking := model.Tracking{ReservationID: reservationID, CampaignID: "1",
+			ClearingPrice: decimal.NewFromFloat(4), ExpiresAt: time.Now().Add(time.Hour)}
+		_, err := repo.TrackEvent(context.Background(), tracking, model.Click)
+		assert.NoError(t, err)
+		assert.False(t, repo.campaigns["1"].Budget.IsNegative(), "budget is %s", repo.campaigns["1"].Budget)
+	}
+
+	assert.True(t, repo.campaigns["1"].Budget.IsZero(), "budget is %s, want 0", repo.campaigns["1"].Budget)
+	assert.False(t, repo.campaigns["1"].Active)
+	assert.Equal(t, model.DeliveryStats{Clicks: 4}, repo.stats["1"])
+}
@@ -0,0 +1,28 @@
+package in_memory
+
+import (
+	"context"
+	"slices"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+)
+
+// UpdateCreativeReview replaces the review of the creative of the campaign.
+func (r *CampaignRepository) UpdateCreativeReview(ctx context.Context, campaignID, creativeID string, review model.Review) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.IndexFunc(r.creatives[campaignID], func(c model.Creative) bool {
+		return c.ID == creativeID
+	})
+	if i < 0 {
+		return pkg.Errorf(pkg.ENOTFOUND, "creative with ID %s not found", creativeID)
+	}
+
+	// the candidates found before still hold the previous slice
+	creatives := slices.Clone(r.creatives[campaignID])
+	creatives[i].Review = review
+	r.creatives[campaignID] = creatives
+	return nil
+}
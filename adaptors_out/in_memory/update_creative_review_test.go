@@ -0,0 +1,88 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_UpdateCreativeReview(t *testing.T) {
+	reviewedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	banner := model.Creative{ID: "banner", CampaignID: "1", Size: model.Size{Width: 300, Height: 250},
+		ImageURL: "https://cdn.example.com/banner.png", Review: model.Review{State: model.Pending}}
+	snippet := model.Creative{ID: "snippet", CampaignID: "1", Size: model.Size{Width: 728, Height: 90},
+		HTML: "<div>ad</div>", Review: model.Review{State: model.Pending}}
+	rejected := model.Review{State: model.Rejected, Reason: "misleading claim", ReviewedAt: reviewedAt}
+
+	withReview := func(c model.Creative, review model.Review) model.Creative {
+		c.Review = review
+		return c
+	}
+
+	tests := []struct {
+		name          string
+		campaignID    string
+		creativeID    string
+		review        model.Review
+		wantCreatives model.Creatives
+		wantErr       error
+	}{
+		{
+			name:          "approves the creative",
+			campaignID:    "1",
+			creativeID:    "snippet",
+			review:        model.Review{State: model.Approved, ReviewedAt: reviewedAt},
+			wantCreatives: model.Creatives{"1": {banner, withReview(snippet, model.Review{State: model.Approved, ReviewedAt: reviewedAt})}},
+		},
+		{
+			name:          "rejects the creative with its reason",
+			campaignID:    "1",
+			creativeID:    "banner",
+			review:        rejected,
+			wantCreatives: model.Creatives{"1": {withReview(banner, rejected), snippet}},
+		},
+		{
+			name:          "unknown creative",
+			campaignID:    "1",
+			creativeID:    "unknown",
+			review:        rejected,
+			wantCreatives: model.Creatives{"1": {banner, snippet}},
+			wantErr:       pkg.Errorf(pkg.ENOTFOUND, "creative with ID unknown not found"),
+		},
+		{
+			name:          "creative of another campaign",
+			campaignID:    "2",
+			creativeID:    "banner",
+			review:        rejected,
+			wantCreatives: model.Creatives{"1": {banner, snippet}},
+			wantErr:       pkg.Errorf(pkg.ENOTFOUND, "creative with ID banner not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			repo.campaigns["1"] = model.Campaign{ID: "1"}
+			creatives := []model.Creative{banner, snippet}
+			repo.creatives["1"] = creatives
+
+			err := repo.UpdateCreativeReview(context.Background(), tt.campaignID, tt.creativeID, tt.review)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCreatives, repo.creatives)
+			// the creatives already found are left untouched
+			assert.Equal(t, []model.Creative{banner, snippet}, creatives)
+		})
+	}
+}
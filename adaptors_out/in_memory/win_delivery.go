@@ -0,0 +1,33 @@
+package in_memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// WinDelivery confirms the reserved delivery like ConfirmDelivery on the win notice of the upstream
+// auction, charging the delivery cost at its clearing price per thousand deliveries instead of the
+// reserved cost: the difference is released back to the campaign budget. The reserved cost is the
+// most charged, as the delivery was never bid above it. Win notices of confirmed deliveries are ignored.
+func (r *CampaignRepository) WinDelivery(ctx context.Context, reservationID string, price decimal.Decimal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.confirmed(reservationID) {
+		return nil
+	}
+
+	d, err := r.pendingReservation(reservationID)
+	if err != nil {
+		return err
+	}
+
+	cost := decimal.Min(r.campaigns[d.CampaignID].DeliveryCostAtCPM(price), d.Cost)
+	r.refund(d.CampaignID, d.Cost.Sub(cost), time.Now())
+	d.Cost = cost
+
+	r.confirm(d)
+	return nil
+}
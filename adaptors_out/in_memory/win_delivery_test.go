@@ -0,0 +1,141 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/pkg/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignRepository_WinDelivery(t *testing.T) {
+	expiresAt := time.Now().Add(5 * time.Minute)
+
+	tests := []struct {
+		name          string
+		campaign      model.Campaign
+		reservation   model.Delivery
+		reservationID string
+		price         decimal.Decimal
+		wantBudget    decimal.Decimal
+		wantActive    bool
+		wantStats     model.DeliveryStats
+		wantErr       error
+	}{
+		{
+			name: "charges the clearing cpm of the upstream auction",
+			campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(95)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: expiresAt},
+			reservationID: "r1",
+			price:         decimal.NewFromFloat(3500),
+			wantBudget:    decimal.NewFromFloat(96.5),
+			wantActive:    true,
+			wantStats:     model.DeliveryStats{Deliveries: 1},
+		},
+		{
+			name: "charges the clearing cpm of cpm campaigns",
+			campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(4), PricingModel: model.CPM,
+				Budget: decimal.NewFromFloat(10)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.RequireFromString("0.004"), ExpiresAt: expiresAt},
+			reservationID: "r1",
+			price:         decimal.NewFromFloat(2.5),
+			wantBudget:    decimal.RequireFromString("10.0015"),
+			wantActive:    true,
+			wantStats:     model.DeliveryStats{Deliveries: 1},
+		},
+		{
+			name: "charges the reserved cost at most",
+			campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(95)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: expiresAt},
+			reservationID: "r1",
+			price:         decimal.NewFromFloat(50000),
+			wantBudget:    decimal.NewFromFloat(95),
+			wantActive:    true,
+			wantStats:     model.DeliveryStats{Deliveries: 1},
+		},
+		{
+			name: "reactivates the campaign the difference affords again",
+			campaign: model.Campaign{ID: "1", Active: false, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(2)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: expiresAt},
+			reservationID: "r1",
+			price:         decimal.NewFromFloat(1000),
+			wantBudget:    decimal.NewFromFloat(6),
+			wantActive:    true,
+			wantStats:     model.DeliveryStats{Deliveries: 1},
+		},
+		{
+			name: "cpc campaign is charged on clicks",
+			campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(0.5), PricingModel: model.CPC,
+				Budget: decimal.NewFromFloat(10)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.Zero, ExpiresAt: expiresAt},
+			reservationID: "r1",
+			price:         decimal.NewFromFloat(3),
+			wantBudget:    decimal.NewFromFloat(10),
+			wantActive:    true,
+			wantStats:     model.DeliveryStats{Deliveries: 1},
+		},
+		{
+			name: "unknown reservation",
+			campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5),
+				Budget: decimal.NewFromFloat(95)},
+			reservation: model.Delivery{ReservationID: "r1", CampaignID: "1",
+				Cost: decimal.NewFromFloat(5), ExpiresAt: expiresAt},
+			reservationID: "r2",
+			price:         decimal.NewFromFloat(3.5),
+			wantBudget:    decimal.NewFromFloat(95),
+			wantActive:    true,
+			wantErr:       pkg.Errorf(pkg.ENOTFOUND, "reservation with ID r2 not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.Init()
+			repo := NewCampaignRepository(&l)
+			repo.campaigns[tt.campaign.ID] = tt.campaign
+			repo.reservations[tt.reservation.ReservationID] = tt.reservation
+
+			err := repo.WinDelivery(context.Background(), tt.reservationID, tt.price)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.NotContains(t, repo.reservations, tt.reservationID)
+			}
+			campaign := repo.campaigns[tt.campaign.ID]
+			assert.True(t, tt.wantBudget.Equal(campaign.Budget),
+				"budget is %s, want %s", campaign.Budget, tt.wantBudget)
+			assert.Equal(t, tt.wantActive, campaign.Active)
+			assert.Equal(t, tt.wantStats, repo.stats[tt.campaign.ID])
+		})
+	}
+
+	t.Run("impression of the won delivery is ignored", func(t *testing.T) {
+		l := logger.Init()
+		repo := NewCampaignRepository(&l)
+		repo.campaigns["1"] = model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5),
+			Budget: decimal.NewFromFloat(95)}
+		repo.reservations["r1"] = model.Delivery{ReservationID: "r1", CampaignID: "1",
+			Cost: decimal.NewFromFloat(5), ExpiresAt: expiresAt}
+
+		assert.NoError(t, repo.WinDelivery(context.Background(), "r1", decimal.NewFromFloat(3000)))
+		assert.NoError(t, repo.ConfirmDelivery(context.Background(), "r1"))
+		assert.NoError(t, repo.WinDelivery(context.Background(), "r1", decimal.NewFromFloat(1000)))
+		assert.True(t, decimal.NewFromFloat(97).Equal(repo.campaigns["1"].Budget))
+		assert.Equal(t, int64(1), repo.stats["1"].Deliveries)
+	})
+}
@@ -15,16 +15,28 @@ func Config() {
 	log := logger.Init()
 
 	campaignRepository := in_memory.NewCampaignRepository(&log)
-	campaignService := campaign.NewService(campaignRepository)
+	exposureTTL := getEnvDuration("EXPOSURE_TTL", "168h")
+	exposureRepository := in_memory.NewExposureRepository(exposureTTL, getEnvDuration("PAGE_VIEW_TTL", "30m"))
+	strategy, countryStrategies := rankingConfig()
+	campaignService := campaign.NewService(campaignRepository, exposureRepository, campaign.Config{
+		Auction:           auctionConfig(),
+		Predictor:         predictorConfig(),
+		Strategy:          strategy,
+		CountryStrategies: countryStrategies,
+		ReservationTTL:    getEnvDuration("RESERVATION_TTL", "5m"),
+	})
+
+	// the cronjobs are started before the server, which blocks until it fails
+	campaignCron := cron.CampaignsHandler{UseCase: campaignService}
+	go campaignCron.CampaignExpirationChecker(log)
+	go campaignCron.ExposureExpirationChecker(log)
+	go campaignCron.ReservationExpirationChecker(log)
 
 	r := http.NewServeMux()
-	web.ConfigureCampaignRoutes(campaignService, r)
+	web.ConfigureCampaignRoutes(campaignService, signerConfig(log), exposureTTL, r)
 
 	err := http.ListenAndServe(":8080", r)
 	if err != nil {
 		panic(err)
 	}
-
-	campaignCron := cron.CampaignsHandler{UseCase: campaignService}
-	go campaignCron.CampaignExpirationChecker(log)
 }
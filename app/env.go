@@ -0,0 +1,139 @@
+package app
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"ad-campaign-delivery/adaptors_in/web"
+	"ad-campaign-delivery/core/campaign"
+	"ad-campaign-delivery/model"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+)
+
+// getEnv returns the value of the environment variable or the fallback when it is unset.
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+// getEnvDecimal parses the environment variable as a decimal, using the fallback when it is unset.
+func getEnvDecimal(key, fallback string) decimal.Decimal {
+	value, err := decimal.NewFromString(getEnv(key, fallback))
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s: %v", key, err))
+	}
+	return value
+}
+
+// getEnvDuration parses the environment variable as a duration, e.g. 24h, using the fallback when it is unset.
+func getEnvDuration(key, fallback string) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, fallback))
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s: %v", key, err))
+	}
+	return value
+}
+
+// auctionConfig reads the auction rules of the deployment:
+//   - AUCTION_TYPE: first_price (default) or second_price
+//   - AUCTION_MIN_INCREMENT: added to the runner-up bid in second price auctions (default 0.01)
+//   - AUCTION_FLOOR: paid by a second price winner without runner-up (default 0)
+func auctionConfig() model.Auction {
+	auctionType, ok := model.AuctionTypes[getEnv("AUCTION_TYPE", string(model.FirstPrice))]
+	if !ok {
+		panic(fmt.Sprintf("invalid AUCTION_TYPE: %s", os.Getenv("AUCTION_TYPE")))
+	}
+
+	return model.Auction{
+		Type:         auctionType,
+		MinIncrement: getEnvDecimal("AUCTION_MIN_INCREMENT", "0.01"),
+		Floor:        getEnvDecimal("AUCTION_FLOOR", "0"),
+	}
+}
+
+// predictorConfig reads the priors of the click and conversion rates predictor:
+//   - PREDICTOR_PRIOR_CTR: click rate per delivery of campaigns without history (default 0.01)
+//   - PREDICTOR_PRIOR_CVR: conversion rate per delivery of campaigns without history (default 0.001)
+//   - PREDICTOR_WEIGHT: number of deliveries the prior is worth (default 1000)
+func predictorConfig() campaign.Predictor {
+	return campaign.HistoricalPredictor{
+		PriorCTR: getEnvDecimal("PREDICTOR_PRIOR_CTR", "0.01"),
+		PriorCVR: getEnvDecimal("PREDICTOR_PRIOR_CVR", "0.001"),
+		Weight:   getEnvDecimal("PREDICTOR_WEIGHT", "1000"),
+	}
+}
+
+// rankingConfig reads the ranking strategies of the deployment, all wrapped by the exploration:
+//   - RANKING_STRATEGY: highest_bid (default), weighted_random or round_robin
+//   - RANKING_STRATEGY_BY_COUNTRY: strategies overriding it per country, e.g. FR:round_robin,ES:weighted_random
+func rankingConfig() (campaign.Strategy, map[model.Country]campaign.Strategy) {
+	strategy := explorationConfig(campaign.NewStrategy(parseRankingStrategy(getEnv("RANKING_STRATEGY", string(model.HighestBid)))))
+
+	countryStrategies := map[model.Country]campaign.Strategy{}
+	byCountry := getEnv("RANKING_STRATEGY_BY_COUNTRY", "")
+	if byCountry == "" {
+		return strategy, countryStrategies
+	}
+
+	for _, entry := range strings.Split(byCountry, ",") {
+		code, name, found := strings.Cut(strings.TrimSpace(entry), ":")
+		country, ok := model.Countries[code]
+		if !found || !ok {
+			panic(fmt.Sprintf("invalid RANKING_STRATEGY_BY_COUNTRY: %s", entry))
+		}
+		countryStrategies[country] = explorationConfig(campaign.NewStrategy(parseRankingStrategy(name)))
+	}
+	return strategy, countryStrategies
+}
+
+func parseRankingStrategy(name string) model.RankingStrategy {
+	strategy, ok := model.RankingStrategies[name]
+	if !ok {
+		panic(fmt.Sprintf("invalid ranking strategy: %s", name))
+	}
+	return strategy
+}
+
+// explorationConfig wraps the ranking strategy to reserve a share of the deliveries for exploration:
+//   - EXPLORATION_MODE: epsilon_greedy or thompson_sampling, no exploration when unset
+//   - EXPLORATION_SHARE: fraction of the deliveries explored (default 0.1)
+func explorationConfig(strategy campaign.Strategy) campaign.Strategy {
+	name := getEnv("EXPLORATION_MODE", "")
+	if name == "" {
+		return strategy
+	}
+
+	mode, ok := model.ExplorationModes[name]
+	if !ok {
+		panic(fmt.Sprintf("invalid EXPLORATION_MODE: %s", name))
+	}
+	share := getEnvDecimal("EXPLORATION_SHARE", "0.1")
+	if share.IsNegative() || share.GreaterThan(decimal.NewFromInt(1)) {
+		panic(fmt.Sprintf("invalid EXPLORATION_SHARE: %s", share))
+	}
+	return campaign.NewExplorationStrategy(mode, strategy, share.InexactFloat64())
+}
+
+// signerConfig reads the signature of the URLs returned to the clients:
+//   - PUBLIC_URL: public URL of the service the URLs point to (default http://localhost:8080)
+//   - URL_SIGNING_SECRET: secret of the signatures, random when unset, so the URLs are
+//     only valid on the instance that issued them until it restarts
+//   - TRACKING_TTL: how long the click and conversion URLs of a delivery are accepted (default 720h)
+func signerConfig(log zerolog.Logger) web.URLSigner {
+	secret := getEnv("URL_SIGNING_SECRET", "")
+	if secret == "" {
+		log.Warn().Msg("URL_SIGNING_SECRET is unset, signing URLs with a random secret")
+		secret = rand.Text()
+	}
+	return web.URLSigner{
+		BaseURL: strings.TrimSuffix(getEnv("PUBLIC_URL", "http://localhost:8080"), "/"),
+		Secret:  []byte(secret),
+		TTL:     getEnvDuration("TRACKING_TTL", "720h"),
+	}
+}
@@ -2,21 +2,73 @@ package campaign
 
 import (
 	"context"
+	crand "crypto/rand"
+	"math/rand/v2"
 	"time"
 
 	"ad-campaign-delivery/model"
 	"ad-campaign-delivery/ports_in"
 	"ad-campaign-delivery/ports_out"
+	"github.com/shopspring/decimal"
 )
 
 type Service struct {
 	ports_in.CampaignService
 	campaignRepository ports_out.CampaignRepository
+	exposureRepository ports_out.ExposureRepository
+	auction            model.Auction
+	predictor          Predictor
+	strategy           Strategy
+	countryStrategies  map[model.Country]Strategy
+	reservationTTL     time.Duration
+	now                func() time.Time
+	rand               func() float64
+	newID              func() string
 }
 
-func NewService(campaignRepository ports_out.CampaignRepository) *Service {
+// Config holds the matching rules of the deployment.
+type Config struct {
+	Auction model.Auction
+	// Predictor estimates the click and conversion rates of the candidates, defaultPredictor when unset.
+	Predictor Predictor
+	// Strategy orders the candidates of the deliveries, highest bid when unset,
+	// unless the country of the delivery has its own strategy in CountryStrategies.
+	Strategy          Strategy
+	CountryStrategies map[model.Country]Strategy
+	// ReservationTTL is how long the cost of a delivery stays reserved waiting for
+	// its impression, defaultReservationTTL when unset.
+	ReservationTTL time.Duration
+}
+
+// defaultReservationTTL leaves the time to load and render the delivered ad.
+const defaultReservationTTL = 5 * time.Minute
+
+func NewService(campaignRepository ports_out.CampaignRepository,
+	exposureRepository ports_out.ExposureRepository, config Config) *Service {
+	predictor := config.Predictor
+	if predictor == nil {
+		predictor = defaultPredictor
+	}
+	strategy := config.Strategy
+	if strategy == nil {
+		strategy = HighestBidStrategy{}
+	}
+	reservationTTL := config.ReservationTTL
+	if reservationTTL <= 0 {
+		reservationTTL = defaultReservationTTL
+	}
+
 	return &Service{
 		campaignRepository: campaignRepository,
+		exposureRepository: exposureRepository,
+		auction:            config.Auction,
+		predictor:          predictor,
+		strategy:           strategy,
+		countryStrategies:  config.CountryStrategies,
+		reservationTTL:     reservationTTL,
+		now:                time.Now,
+		rand:               rand.Float64,
+		newID:              crand.Text,
 	}
 }
 
@@ -29,18 +81,95 @@ func (s *Service) Create(ctx context.Context, campaign model.Campaign, activeDay
 	}
 
 	campaign.CreatedAt = now
-	if campaign.Budget.GreaterThanOrEqual(campaign.Bid) {
+	if campaign.Budget.GreaterThanOrEqual(campaign.UnitCost()) {
 		campaign.Active = true
 	}
 
 	return s.campaignRepository.CreateCampaign(ctx, campaign)
 }
 
-// Match retrieves the best matching campaign lookup.
-func (s *Service) Match(ctx context.Context, country model.Country, device model.Device, os model.OS) (*model.BidLookup, error) {
-	return s.campaignRepository.MatchCampaign(ctx, country, device, os)
+// SetFloorRule sets the minimum bid accepted for the targeting of the rule.
+func (s *Service) SetFloorRule(ctx context.Context, rule model.FloorRule) error {
+	return s.campaignRepository.SaveFloorRule(ctx, rule)
+}
+
+// SetDeal saves the private marketplace deal, replacing the existing deal with the same ID.
+func (s *Service) SetDeal(ctx context.Context, deal model.Deal) error {
+	return s.campaignRepository.SaveDeal(ctx, deal)
+}
+
+// AddCreative adds the creative to its campaign, the creatives being selected in creation order.
+// The creative is pending until its review approves it for delivery.
+func (s *Service) AddCreative(ctx context.Context, creative model.Creative) error {
+	creative.Review = model.Review{State: model.Pending}
+	return s.campaignRepository.CreateCreative(ctx, creative)
+}
+
+// ListCreatives returns the creatives of the campaign in creation order.
+func (s *Service) ListCreatives(ctx context.Context, campaignID string) ([]model.Creative, error) {
+	return s.campaignRepository.FindCreatives(ctx, campaignID)
+}
+
+// ReviewCreative approves the creative of the campaign for delivery, or rejects it for the reason
+// of the review. Creatives can be reviewed again, e.g. to approve them once fixed.
+func (s *Service) ReviewCreative(ctx context.Context, campaignID, creativeID string, review model.Review) error {
+	review.ReviewedAt = s.now()
+	return s.campaignRepository.UpdateCreativeReview(ctx, campaignID, creativeID, review)
+}
+
+// RemoveCreative removes the creative from the campaign. The clicks of the deliveries already
+// made with it are redirected to the click URL of the campaign.
+func (s *Service) RemoveCreative(ctx context.Context, campaignID, creativeID string) error {
+	return s.campaignRepository.DeleteCreative(ctx, campaignID, creativeID)
+}
+
+// ConfirmDelivery confirms the reserved delivery on its impression, which charges it for good.
+// Repeated confirmations of the delivery are ignored.
+func (s *Service) ConfirmDelivery(ctx context.Context, reservationID string) error {
+	return s.campaignRepository.ConfirmDelivery(ctx, reservationID)
+}
+
+// WinDelivery confirms the reserved delivery on the win notice of the upstream auction, charging
+// the clearing price of that auction, per thousand deliveries, instead of the reserved cost.
+func (s *Service) WinDelivery(ctx context.Context, reservationID string, price decimal.Decimal) error {
+	return s.campaignRepository.WinDelivery(ctx, reservationID, price)
+}
+
+// ReleaseDelivery releases the reserved delivery on the loss notice of the upstream auction,
+// refunding its cost to the campaign budget and forgetting it from the history of the user and
+// the page view.
+func (s *Service) ReleaseDelivery(ctx context.Context, reservationID string) error {
+	delivery, err := s.campaignRepository.ReleaseDelivery(ctx, reservationID)
+	if err != nil || delivery.ReservationID == "" {
+		return err
+	}
+	return s.exposureRepository.ForgetDeliveries(ctx, []model.Delivery{delivery})
+}
+
+// TrackEvent records the click, conversion or video event of the tracked delivery once, charging it when
+// the event is billable by the campaign pricing model and ignoring the repeated pings, and returns the
+// campaign of the delivery.
+func (s *Service) TrackEvent(ctx context.Context, tracking model.Tracking, event model.EventType) (model.Campaign, error) {
+	return s.campaignRepository.TrackEvent(ctx, tracking, event)
 }
 
 func (s *Service) DeactivateExpiredCampaigns() {
 	s.campaignRepository.DeactivateExpiredCampaigns()
 }
+
+// ReleaseExpiredDeliveries releases the cost of the deliveries whose impression did not come
+// in time back to the campaign budgets, and forgets them from the history of their users and
+// page views.
+func (s *Service) ReleaseExpiredDeliveries() {
+	released := s.campaignRepository.ReleaseExpiredDeliveries()
+	if len(released) == 0 {
+		return
+	}
+	// the sweeper has no caller to report to, and deliveries left unforgotten
+	// still expire from the history with the exposure ttl
+	_ = s.exposureRepository.ForgetDeliveries(context.Background(), released)
+}
+
+func (s *Service) DeleteExpiredExposures() {
+	s.exposureRepository.DeleteExpiredExposures()
+}
@@ -11,6 +11,11 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestNewService_DefaultPredictor(t *testing.T) {
+	service := NewService(&ports_out.CampaignRepositoryMock{}, &ports_out.ExposureRepositoryMock{}, Config{})
+	assert.Equal(t, defaultPredictor, service.predictor)
+}
+
 func TestCampaignService_Create(t *testing.T) {
 	timeNowMock := time.Now()
 	expiresAtMock := timeNowMock.AddDate(0, 0, 30)
@@ -33,6 +38,41 @@ func TestCampaignService_Create(t *testing.T) {
 				Active: false, CreatedAt: timeNowMock},
 		},
 
+		{
+			name: "house campaign without budget is active",
+			inputCampaign: model.Campaign{
+				ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+				Bid: decimal.NewFromFloat(1), Priority: model.House, Budget: decimal.Zero},
+
+			CampaignToPersist: model.Campaign{
+				ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+				Bid: decimal.NewFromFloat(1), Priority: model.House, Budget: decimal.Zero,
+				Active: true, CreatedAt: timeNowMock},
+		},
+
+		{
+			name: "impression goal campaign without budget is active",
+			inputCampaign: model.Campaign{
+				ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+				Bid: decimal.NewFromFloat(2), Priority: model.Guaranteed, ImpressionGoal: 1000},
+
+			CampaignToPersist: model.Campaign{
+				ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+				Bid: decimal.NewFromFloat(2), Priority: model.Guaranteed, ImpressionGoal: 1000,
+				Active: true, CreatedAt: timeNowMock},
+		},
+
+		{
+			name: "cpm budget is lower than bid, but affords a thousand deliveries",
+			inputCampaign: model.Campaign{
+				ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+				Bid: decimal.NewFromFloat(50), PricingModel: model.CPM, Budget: decimal.NewFromFloat(10)},
+
+			CampaignToPersist: model.Campaign{
+				ID: "1", Country: model.France, Device: model.Mobile, OS: model.Android,
+				Bid: decimal.NewFromFloat(50), PricingModel: model.CPM, Budget: decimal.NewFromFloat(10),
+				Active: true, CreatedAt: timeNowMock},
+		},
 		{
 			name: "budget is equal to bid, with expiration day",
 			inputCampaign: model.Campaign{
@@ -79,53 +119,193 @@ func TestCampaignService_Create(t *testing.T) {
 				},
 			}
 
-			service := NewService(campaignRepo)
+			service := NewService(campaignRepo, &ports_out.ExposureRepositoryMock{}, Config{})
 			err := service.Create(context.Background(), tt.inputCampaign, tt.activeDays)
 			assert.NoError(t, err)
 		})
 	}
 }
 
-func TestCampaignService_Match(t *testing.T) {
+func TestCampaignService_SetFloorRule(t *testing.T) {
+	rule := model.FloorRule{
+		Targeting: model.Targeting{Country: model.France, Device: model.Mobile},
+		Floor:     decimal.NewFromFloat(1.5),
+	}
+	campaignRepo := &ports_out.CampaignRepositoryMock{
+		SaveFloorRuleFunc: func(ctx context.Context, r model.FloorRule) error {
+			assert.Equal(t, rule, r)
+			return nil
+		},
+	}
+
+	service := NewService(campaignRepo, &ports_out.ExposureRepositoryMock{}, Config{})
+	err := service.SetFloorRule(context.Background(), rule)
+	assert.NoError(t, err)
+	assert.Len(t, campaignRepo.SaveFloorRuleCalls(), 1)
+}
+
+func TestCampaignService_Creatives(t *testing.T) {
+	creative := model.Creative{ID: "banner", CampaignID: "123", Size: model.Size{Width: 300, Height: 250},
+		ImageURL: "https://cdn.example.com/banner.png"}
+	campaignRepo := &ports_out.CampaignRepositoryMock{
+		CreateCreativeFunc: func(ctx context.Context, c model.Creative) error {
+			// new creatives are pending until reviewed
+			pending := creative
+			pending.Review = model.Review{State: model.Pending}
+			assert.Equal(t, pending, c)
+			return nil
+		},
+		FindCreativesFunc: func(ctx context.Context, campaignID string) ([]model.Creative, error) {
+			assert.Equal(t, "123", campaignID)
+			return []model.Creative{creative}, nil
+		},
+		DeleteCreativeFunc: func(ctx context.Context, campaignID, creativeID string) error {
+			assert.Equal(t, "123", campaignID)
+			assert.Equal(t, "banner", creativeID)
+			return nil
+		},
+	}
+
+	service := NewService(campaignRepo, &ports_out.ExposureRepositoryMock{}, Config{})
+	assert.NoError(t, service.AddCreative(context.Background(), creative))
+	creatives, err := service.ListCreatives(context.Background(), "123")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Creative{creative}, creatives)
+	assert.NoError(t, service.RemoveCreative(context.Background(), "123", "banner"))
+	assert.Len(t, campaignRepo.CreateCreativeCalls(), 1)
+	assert.Len(t, campaignRepo.FindCreativesCalls(), 1)
+	assert.Len(t, campaignRepo.DeleteCreativeCalls(), 1)
+}
+
+func TestCampaignService_ReviewCreative(t *testing.T) {
+	reviewedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	campaignRepo := &ports_out.CampaignRepositoryMock{
+		UpdateCreativeReviewFunc: func(ctx context.Context, campaignID, creativeID string, review model.Review) error {
+			assert.Equal(t, "123", campaignID)
+			assert.Equal(t, "banner", creativeID)
+			assert.Equal(t, model.Review{State: model.Rejected, Reason: "misleading claim", ReviewedAt: reviewedAt}, review)
+			return nil
+		},
+	}
+
+	service := NewService(campaignRepo, &ports_out.ExposureRepositoryMock{}, Config{})
+	service.now = func() time.Time { return reviewedAt }
+	err := service.ReviewCreative(context.Background(), "123", "banner",
+		model.Review{State: model.Rejected, Reason: "misleading claim"})
+	assert.NoError(t, err)
+	assert.Len(t, campaignRepo.UpdateCreativeReviewCalls(), 1)
+}
+
+func TestCampaignService_ConfirmDelivery(t *testing.T) {
+	campaignRepo := &ports_out.CampaignRepositoryMock{
+		ConfirmDeliveryFunc: func(ctx context.Context, reservationID string) error {
+			assert.Equal(t, "res1", reservationID)
+			return nil
+		},
+	}
+
+	service := NewService(campaignRepo, &ports_out.ExposureRepositoryMock{}, Config{})
+	err := service.ConfirmDelivery(context.Background(), "res1")
+	assert.NoError(t, err)
+	assert.Len(t, campaignRepo.ConfirmDeliveryCalls(), 1)
+}
+
+func TestCampaignService_WinDelivery(t *testing.T) {
+	campaignRepo := &ports_out.CampaignRepositoryMock{
+		WinDeliveryFunc: func(ctx context.Context, reservationID string, price decimal.Decimal) error {
+			assert.Equal(t, "res1", reservationID)
+			assert.True(t, decimal.NewFromFloat(1.25).Equal(price))
+			return nil
+		},
+	}
+
+	service := NewService(campaignRepo, &ports_out.ExposureRepositoryMock{}, Config{})
+	err := service.WinDelivery(context.Background(), "res1", decimal.NewFromFloat(1.25))
+	assert.NoError(t, err)
+	assert.Len(t, campaignRepo.WinDeliveryCalls(), 1)
+}
+
+func TestCampaignService_ReleaseDelivery(t *testing.T) {
+	reserved := model.Delivery{ReservationID: "res1", CampaignID: "camp123", UserID: "user1",
+		ReservedAt: time.Now()}
+
 	tests := []struct {
-		name      string
-		country   model.Country
-		Device    model.Device
-		OS        model.OS
-		bidLookup *model.BidLookup
+		name       string
+		released   model.Delivery
+		wantForget bool
 	}{
 		{
-			name:    "delivers bid",
-			country: model.France,
-			Device:  model.Mobile,
-			OS:      model.Android,
-			bidLookup: &model.BidLookup{
-				ID:  "123",
-				Bid: decimal.NewFromFloat(5),
-			},
+			name:       "forgets the released delivery",
+			released:   reserved,
+			wantForget: true,
+		},
+		{
+			name:     "confirmed delivery is not forgotten",
+			released: model.Delivery{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			campaignRepo := &ports_out.CampaignRepositoryMock{
-				MatchCampaignFunc: func(ctx context.Context, country model.Country,
-					device model.Device, os model.OS) (*model.BidLookup, error) {
-
-					assert.Equal(t, tt.country, country)
-					assert.Equal(t, tt.Device, device)
-					assert.Equal(t, tt.OS, os)
-
-					return tt.bidLookup, nil
+				ReleaseDeliveryFunc: func(ctx context.Context, reservationID string) (model.Delivery, error) {
+					assert.Equal(t, "res1", reservationID)
+					return tt.released, nil
+				},
+			}
+			exposureRepo := &ports_out.ExposureRepositoryMock{
+				ForgetDeliveriesFunc: func(ctx context.Context, deliveries []model.Delivery) error {
+					assert.Equal(t, []model.Delivery{reserved}, deliveries)
+					return nil
 				},
 			}
 
-			service := NewService(campaignRepo)
-			bidLookup, err := service.Match(context.Background(), tt.country, tt.Device, tt.OS)
+			service := NewService(campaignRepo, exposureRepo, Config{})
+			err := service.ReleaseDelivery(context.Background(), "res1")
 			assert.NoError(t, err)
-			if tt.bidLookup != nil {
-				assert.Equal(t, tt.bidLookup, bidLookup)
+			assert.Len(t, campaignRepo.ReleaseDeliveryCalls(), 1)
+			if tt.wantForget {
+				assert.Len(t, exposureRepo.ForgetDeliveriesCalls(), 1)
+			} else {
+				assert.Len(t, exposureRepo.ForgetDeliveriesCalls(), 0)
 			}
 		})
 	}
 }
+
+func TestCampaignService_ReleaseExpiredDeliveries(t *testing.T) {
+	expired := []model.Delivery{{ReservationID: "res1", CampaignID: "camp123", UserID: "user1",
+		ReservedAt: time.Now().Add(-time.Hour)}}
+	campaignRepo := &ports_out.CampaignRepositoryMock{
+		ReleaseExpiredDeliveriesFunc: func() []model.Delivery {
+			return expired
+		},
+	}
+	exposureRepo := &ports_out.ExposureRepositoryMock{
+		ForgetDeliveriesFunc: func(ctx context.Context, deliveries []model.Delivery) error {
+			assert.Equal(t, expired, deliveries)
+			return nil
+		},
+	}
+
+	service := NewService(campaignRepo, exposureRepo, Config{})
+	service.ReleaseExpiredDeliveries()
+	assert.Len(t, exposureRepo.ForgetDeliveriesCalls(), 1)
+}
+
+func TestCampaignService_TrackEvent(t *testing.T) {
+	tracking := model.Tracking{ReservationID: "res1", CampaignID: "camp123", ExpiresAt: time.Now().Add(time.Hour)}
+	campaignRepo := &ports_out.CampaignRepositoryMock{
+		TrackEventFunc: func(ctx context.Context, got model.Tracking, event model.EventType) (model.Campaign, error) {
+			assert.Equal(t, tracking, got)
+			assert.Equal(t, model.Click, event)
+			return model.Campaign{ID: "camp123", ClickURL: "https://example.com"}, nil
+		},
+	}
+
+	service := NewService(campaignRepo, &ports_out.ExposureRepositoryMock{}, Config{})
+	campaign, err := service.TrackEvent(context.Background(), tracking, model.Click)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", campaign.ClickURL)
+	assert.Len(t, campaignRepo.TrackEventCalls(), 1)
+}
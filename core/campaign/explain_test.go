@@ -0,0 +1,190 @@
+package campaign
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/ports_out"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignService_Explain(t *testing.T) {
+	matchedAt := time.Date(2025, 6, 1, 20, 30, 0, 0, time.UTC)
+	createdAt := matchedAt.AddDate(0, 0, -1)
+
+	banner := model.Creative{ID: "banner", Format: model.Banner, Review: model.Review{State: model.Approved}}
+	candidate := func(id string, active bool, bid float64) model.Candidate {
+		return model.Candidate{Campaign: model.Campaign{ID: id, Active: active,
+			Bid: decimal.NewFromFloat(bid), Budget: decimal.NewFromFloat(100), CreatedAt: createdAt},
+			Creatives: []model.Creative{banner}}
+	}
+	withCategory := func(c model.Candidate, advertiser string) model.Candidate {
+		c.Advertiser = advertiser
+		c.Category = "automotive"
+		return c
+	}
+	expired := candidate("expired", false, 12)
+	expired.ExpiresAt = matchedAt.Add(-time.Hour)
+	outOfBudget := candidate("out-of-budget", false, 9)
+	outOfBudget.Budget = decimal.NewFromFloat(1)
+	winner := candidate("winner", true, 8)
+	winner.Advertiser = "acme"
+	duplicate := candidate("duplicate", true, 7)
+	duplicate.Advertiser = "acme"
+
+	type verdict struct {
+		id            string
+		rank          int
+		verdict       model.Verdict
+		clearingPrice decimal.Decimal
+		dealID        string
+	}
+
+	tests := []struct {
+		name         string
+		candidates   []model.Candidate
+		deals        []model.Deal
+		req          model.MatchRequest
+		wantVerdicts []verdict
+		wantReason   model.NoMatchReason
+	}{
+		{
+			name: "verdict of every candidate in ranking order",
+			candidates: []model.Candidate{
+				candidate("below-floor", true, 3),
+				candidate("last", true, 5),
+				candidate("runner-up", true, 6),
+				duplicate,
+				winner,
+				outOfBudget,
+				expired,
+			},
+			req: model.MatchRequest{UniqueAdvertisers: true},
+			wantVerdicts: []verdict{
+				{id: "expired", rank: 1, verdict: model.Expired},
+				{id: "out-of-budget", rank: 2, verdict: model.OutOfBudget},
+				{id: "winner", rank: 3, verdict: model.Won, clearingPrice: decimal.NewFromFloat(8)},
+				{id: "duplicate", rank: 4, verdict: model.DuplicateAdvertiser},
+				{id: "runner-up", rank: 5, verdict: model.Outranked},
+				{id: "last", rank: 6, verdict: model.Outranked},
+				{id: "below-floor", rank: 7, verdict: model.Verdict(model.BelowFloor)},
+			},
+		},
+		{
+			name: "campaigns outside the deals of a private auction",
+			candidates: []model.Candidate{
+				candidate("open", true, 10),
+				candidate("in-deal", true, 3),
+			},
+			deals: []model.Deal{{ID: "deal1", Price: decimal.NewFromFloat(2), Campaigns: []string{"in-deal"}}},
+			req:   model.MatchRequest{DealIDs: []string{"deal1"}, PrivateAuction: true},
+			wantVerdicts: []verdict{
+				{id: "open", rank: 1, verdict: model.NotInDeal},
+				{id: "in-deal", rank: 2, verdict: model.Won, clearingPrice: decimal.NewFromFloat(2), dealID: "deal1"},
+			},
+		},
+		{
+			name: "competitor of the category of the winner outranked",
+			candidates: []model.Candidate{
+				withCategory(candidate("globex", true, 9), "globex"),
+				withCategory(candidate("acme", true, 10), "acme"),
+			},
+			wantVerdicts: []verdict{
+				{id: "acme", rank: 1, verdict: model.Won, clearingPrice: decimal.NewFromFloat(10)},
+				{id: "globex", rank: 2, verdict: model.Outranked},
+			},
+		},
+		{
+			name: "no match",
+			candidates: []model.Candidate{
+				candidate("inactive", false, 10),
+				candidate("below-floor", true, 3),
+			},
+			wantVerdicts: []verdict{
+				{id: "inactive", rank: 1, verdict: model.Inactive},
+				{id: "below-floor", rank: 2, verdict: model.Verdict(model.BelowFloor)},
+			},
+			wantReason: model.BelowFloor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignRepo := &ports_out.CampaignRepositoryMock{
+				FindCandidatesFunc: func(ctx context.Context, tg model.Targeting, formats []model.CreativeFormat, sizes []model.Size) ([]model.Candidate, error) {
+					return tt.candidates, nil
+				},
+				FindFloorFunc: func(ctx context.Context, tg model.Targeting) (decimal.Decimal, error) {
+					return decimal.NewFromFloat(4), nil
+				},
+				FindDealsFunc: func(ctx context.Context, ids []string) ([]model.Deal, error) {
+					return tt.deals, nil
+				},
+			}
+			exposureRepo := &ports_out.ExposureRepositoryMock{}
+
+			service := NewService(campaignRepo, exposureRepo, Config{Predictor: HistoricalPredictor{}})
+			service.now = func() time.Time {
+				return matchedAt
+			}
+			explanation, err := service.Explain(context.Background(), tt.req)
+
+			assert.NoError(t, err)
+			assert.True(t, decimal.NewFromFloat(4).Equal(explanation.Floor))
+			assert.Equal(t, tt.wantReason, explanation.NoMatchReason)
+			assert.Len(t, explanation.Candidates, len(tt.wantVerdicts))
+			for i, want := range tt.wantVerdicts {
+				got := explanation.Candidates[i]
+				assert.Equal(t, want.id, got.ID)
+				assert.Equal(t, want.rank, got.Rank)
+				assert.Equal(t, want.verdict, got.Verdict, "verdict of %s", got.ID)
+				assert.True(t, want.clearingPrice.Equal(got.ClearingPrice),
+					"clearing price of %s is %s, want %s", got.ID, got.ClearingPrice, want.clearingPrice)
+				assert.Equal(t, want.dealID, got.DealID)
+			}
+
+			// nothing is delivered nor recorded
+			assert.Len(t, campaignRepo.DeliverCampaignsCalls(), 0)
+			assert.Len(t, exposureRepo.RecordExposuresCalls(), 0)
+			assert.Len(t, exposureRepo.RecordPageViewCalls(), 0)
+		})
+	}
+}
+
+func TestCampaignService_Explain_KeepsTheTurns(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 20, 30, 0, 0, time.UTC)
+	banner := model.Creative{ID: "banner", Format: model.Banner, Review: model.Review{State: model.Approved}}
+	candidates := []model.Candidate{
+		{Campaign: model.Campaign{ID: "new", Active: true, Bid: decimal.NewFromFloat(5), CreatedAt: createdAt},
+			Creatives: []model.Creative{banner}},
+		{Campaign: model.Campaign{ID: "old", Active: true, Bid: decimal.NewFromFloat(5),
+			CreatedAt: createdAt.Add(-time.Hour)}, Creatives: []model.Creative{banner}},
+	}
+	campaignRepo := &ports_out.CampaignRepositoryMock{
+		FindCandidatesFunc: func(ctx context.Context, tg model.Targeting, formats []model.CreativeFormat, sizes []model.Size) ([]model.Candidate, error) {
+			return candidates, nil
+		},
+		FindFloorFunc: func(ctx context.Context, tg model.Targeting) (decimal.Decimal, error) {
+			return decimal.Zero, nil
+		},
+		DeliverCampaignsFunc: func(ctx context.Context, deliveries []model.Delivery) error {
+			return nil
+		},
+	}
+	service := NewService(campaignRepo, &ports_out.ExposureRepositoryMock{},
+		Config{Predictor: HistoricalPredictor{}, Strategy: NewRoundRobinStrategy()})
+
+	for range 2 {
+		explanation, err := service.Explain(context.Background(), model.MatchRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, "old", explanation.Candidates[0].ID)
+	}
+
+	// the explanations did not take the turn of the old campaign
+	result, err := service.Match(context.Background(), model.MatchRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "old", result.Matches[0].ID)
+}
@@ -0,0 +1,141 @@
+package campaign
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"ad-campaign-delivery/model"
+	"github.com/shopspring/decimal"
+)
+
+// NewExplorationStrategy returns a strategy reserving the share of the deliveries to explore
+// with the exploration mode, and ordering the others with the exploit strategy.
+func NewExplorationStrategy(mode model.ExplorationMode, exploit Strategy, share float64) Strategy {
+	var explore Strategy
+	switch mode {
+	case model.ThompsonSampling:
+		explore = ThompsonSamplingStrategy{Rand: rand.Float64}
+	default:
+		explore = RandomStrategy{Rand: rand.Float64}
+	}
+
+	return ExplorationStrategy{Exploit: exploit, Explore: explore, Share: share, Rand: rand.Float64}
+}
+
+// ExplorationStrategy orders a share of the deliveries with the Explore strategy, so campaigns
+// the Exploit strategy would keep behind get the traffic needed to learn their performance.
+type ExplorationStrategy struct {
+	Exploit Strategy
+	Explore Strategy
+	// Share is the fraction of the deliveries explored, between 0 and 1.
+	Share float64
+	// Rand returns a pseudo-random number in [0.0, 1.0).
+	Rand func() float64
+}
+
+// Order implements Strategy.
+func (s ExplorationStrategy) Order(candidates []model.Candidate) []model.Candidate {
+	if s.Rand() < s.Share {
+		return s.Explore.Order(candidates)
+	}
+	return s.Exploit.Order(candidates)
+}
+
+// Peek implements Peeker.
+func (s ExplorationStrategy) Peek(candidates []model.Candidate) []model.Candidate {
+	if s.Rand() < s.Share {
+		return peek(s.Explore, candidates)
+	}
+	return peek(s.Exploit, candidates)
+}
+
+// RandomStrategy shuffles the candidates uniformly, the exploration of epsilon-greedy.
+type RandomStrategy struct {
+	// Rand returns a pseudo-random number in [0.0, 1.0).
+	Rand func() float64
+}
+
+// Order implements Strategy.
+func (s RandomStrategy) Order(candidates []model.Candidate) []model.Candidate {
+	ordered := slices.Clone(candidates)
+	for i := len(ordered) - 1; i > 0; i-- {
+		j := int(s.Rand() * float64(i+1))
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	}
+	return ordered
+}
+
+// ThompsonSamplingStrategy orders the candidates by an effective CPM sampled from the Beta
+// posterior of the rate of their billable event, built from their delivery and event counters.
+// Campaigns with few deliveries have wide posteriors, so they are regularly sampled high.
+// Campaigns paying per delivery have a known value and keep their effective CPM.
+type ThompsonSamplingStrategy struct {
+	// Rand returns a pseudo-random number in [0.0, 1.0).
+	Rand func() float64
+}
+
+// Order implements Strategy.
+func (s ThompsonSamplingStrategy) Order(candidates []model.Candidate) []model.Candidate {
+	ordered := HighestBidStrategy{}.Order(candidates)
+
+	samples := make(map[string]float64, len(ordered))
+	for _, c := range ordered {
+		samples[c.ID] = s.sampleECPM(c)
+	}
+
+	slices.SortStableFunc(ordered, func(a, b model.Candidate) int {
+		return cmp.Compare(samples[b.ID], samples[a.ID])
+	})
+	return ordered
+}
+
+func (s ThompsonSamplingStrategy) sampleECPM(c model.Candidate) float64 {
+	var events int64
+	switch c.PricingModel {
+	case model.CPC:
+		events = c.Stats.Clicks
+	case model.CPA:
+		events = c.Stats.Conversions
+	default:
+		return c.ECPM.InexactFloat64()
+	}
+
+	failures := max(c.Stats.Deliveries-events, 0)
+	rate := s.beta(float64(events+1), float64(failures+1))
+	return c.EffectiveCPM(model.Prediction{
+		CTR: decimal.NewFromFloat(rate),
+		CVR: decimal.NewFromFloat(rate),
+	}).InexactFloat64()
+}
+
+// beta samples the Beta(a, b) distribution from two Gamma samples.
+func (s ThompsonSamplingStrategy) beta(a, b float64) float64 {
+	x := s.gamma(a)
+	y := s.gamma(b)
+	return x / (x + y)
+}
+
+// gamma samples the Gamma(shape, 1) distribution, shape >= 1, with the Marsaglia and Tsang method.
+func (s ThompsonSamplingStrategy) gamma(shape float64) float64 {
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := s.normal()
+		v := math.Pow(1+c*x, 3)
+		if v <= 0 {
+			continue
+		}
+		u := s.Rand()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
+
+// normal samples the standard normal distribution with the Box-Muller transform.
+func (s ThompsonSamplingStrategy) normal() float64 {
+	u := 1 - s.Rand()
+	return math.Sqrt(-2*math.Log(u)) * math.Cos(2*math.Pi*s.Rand())
+}
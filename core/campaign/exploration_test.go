@@ -0,0 +1,115 @@
+package campaign
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplorationStrategy_Order(t *testing.T) {
+	now := time.Now()
+	candidates := []model.Candidate{
+		scoredCandidate("new", 1, now),
+		scoredCandidate("best", 8, now),
+	}
+	strategy := ExplorationStrategy{
+		Exploit: HighestBidStrategy{},
+		Explore: reverseStrategy{},
+		Share:   0.1,
+		Rand:    rand.New(rand.NewPCG(1, 2)).Float64,
+	}
+
+	draws := 10000
+	explored := 0
+	for range draws {
+		if strategy.Order(candidates)[0].ID == "new" {
+			explored++
+		}
+	}
+
+	assert.InDelta(t, 0.1, float64(explored)/float64(draws), 0.01)
+}
+
+func TestRandomStrategy_Order(t *testing.T) {
+	now := time.Now()
+	candidates := []model.Candidate{
+		scoredCandidate("a", 1, now),
+		scoredCandidate("b", 2, now),
+		scoredCandidate("c", 3, now),
+	}
+	strategy := RandomStrategy{Rand: rand.New(rand.NewPCG(1, 2)).Float64}
+
+	draws := 9000
+	firsts := map[string]int{}
+	for range draws {
+		ordered := strategy.Order(candidates)
+		assert.ElementsMatch(t, []string{"a", "b", "c"}, candidateIDs(ordered))
+		firsts[ordered[0].ID]++
+	}
+
+	for _, id := range []string{"a", "b", "c"} {
+		assert.InDelta(t, 1.0/3, float64(firsts[id])/float64(draws), 0.02)
+	}
+}
+
+func TestThompsonSamplingStrategy_Order(t *testing.T) {
+	now := time.Now()
+	cpc := func(id string, deliveries, clicks int64) model.Candidate {
+		return model.Candidate{
+			Campaign:     model.Campaign{ID: id, PricingModel: model.CPC, Bid: decimal.NewFromFloat(1), CreatedAt: now},
+			Stats:        model.DeliveryStats{Deliveries: deliveries, Clicks: clicks},
+			EffectiveBid: decimal.NewFromFloat(1),
+		}
+	}
+	// known CTR of 2% for a bid of 1 per click
+	proven := cpc("proven", 100000, 2000)
+	proven.ECPM = decimal.NewFromFloat(20)
+
+	tests := []struct {
+		name        string
+		candidate   model.Candidate
+		wantFirstAt [2]float64
+	}{
+		{
+			name:        "a new campaign is regularly sampled above a proven one",
+			candidate:   cpc("new", 0, 0),
+			wantFirstAt: [2]float64{0.5, 1},
+		},
+		{
+			name:        "a campaign proven worse is almost never sampled first",
+			candidate:   cpc("worse", 100000, 1000),
+			wantFirstAt: [2]float64{0, 0.01},
+		},
+		{
+			name: "a campaign paying per delivery keeps its effective cpm",
+			candidate: model.Candidate{
+				Campaign:     model.Campaign{ID: "cpm", PricingModel: model.CPM, Bid: decimal.NewFromFloat(25)},
+				EffectiveBid: decimal.NewFromFloat(25),
+				ECPM:         decimal.NewFromFloat(25),
+			},
+			wantFirstAt: [2]float64{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := ThompsonSamplingStrategy{Rand: rand.New(rand.NewPCG(1, 2)).Float64}
+
+			draws := 1000
+			firsts := 0
+			for range draws {
+				if strategy.Order([]model.Candidate{proven, tt.candidate})[0].ID == tt.candidate.ID {
+					firsts++
+				}
+			}
+
+			share := float64(firsts) / float64(draws)
+			assert.GreaterOrEqual(t, share, tt.wantFirstAt[0])
+			assert.LessOrEqual(t, share, tt.wantFirstAt[1])
+		})
+	}
+}
@@ -0,0 +1,456 @@
+package campaign
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"github.com/shopspring/decimal"
+)
+
+// maxMatchAttempts is how many times a match is retried when a winner
+// could no longer be delivered by the time its budget was deducted.
+const maxMatchAttempts = 3
+
+// Match retrieves the best matching campaigns for the slots of the request, each delivered with its
+// first approved creative fitting the slot, and reserves the cost of their deliveries until their
+// impression confirms them, or the reservation expires. When no campaign can be delivered, the
+// result holds the reason of the best ranked active candidate instead. Nothing is reserved once
+// the context is done, the caller no longer waiting for the deliveries.
+func (s *Service) Match(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
+	var err error
+	for range maxMatchAttempts {
+		var result model.MatchResult
+		result, err = s.match(ctx, req)
+		if pkg.ErrorCode(err) != pkg.ECONFLICT {
+			return result, err
+		}
+	}
+	return model.MatchResult{}, err
+}
+
+func (s *Service) match(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
+	result, err := s.runAuction(ctx, req, false)
+	if err != nil {
+		return model.MatchResult{}, err
+	}
+	if len(result.winners) == 0 {
+		return model.MatchResult{NoMatchReason: result.reason}, nil
+	}
+
+	// the auction outlasted the deadline of the caller, which would never deliver the winners
+	if err := ctx.Err(); err != nil {
+		return model.MatchResult{}, err
+	}
+
+	deliveries := make([]model.Delivery, len(result.winners))
+	for i, c := range result.winners {
+		result.matches[i].ReservationID = s.newID()
+		deliveries[i] = model.Delivery{
+			ReservationID: result.matches[i].ReservationID,
+			CampaignID:    c.ID,
+			Cost:          c.DeliveryCost(result.matches[i].ClearingPrice),
+			ExpiresAt:     result.now.Add(s.reservationTTL),
+			UserID:        req.UserID,
+			PageViewID:    req.PageViewID,
+			Category:      c.Category,
+			Advertiser:    c.Advertiser,
+			ReservedAt:    result.now,
+		}
+	}
+	err = s.campaignRepository.DeliverCampaigns(ctx, deliveries)
+	if err != nil {
+		return model.MatchResult{}, err
+	}
+
+	if req.UserID != "" {
+		campaignIDs := make([]string, len(result.matches))
+		for i, m := range result.matches {
+			campaignIDs[i] = m.ID
+		}
+		err = s.exposureRepository.RecordExposures(ctx, req.UserID, campaignIDs, result.now)
+		if err != nil {
+			return model.MatchResult{}, err
+		}
+	}
+
+	if req.PageViewID != "" {
+		delivered := model.PageView{}
+		for _, c := range result.winners {
+			delivered.Add(c.Campaign)
+		}
+		err = s.exposureRepository.RecordPageView(ctx, req.PageViewID, delivered, result.now)
+		if err != nil {
+			return model.MatchResult{}, err
+		}
+	}
+	return model.MatchResult{Matches: result.matches}, nil
+}
+
+// Explain runs the auction of the request like Match, without delivering anything nor recording
+// the deliveries of the user and the page view, nor taking the turns of the ranking strategy, and
+// returns the verdict of every candidate.
+func (s *Service) Explain(ctx context.Context, req model.MatchRequest) (model.Explanation, error) {
+	result, err := s.runAuction(ctx, req, true)
+	if err != nil {
+		return model.Explanation{}, err
+	}
+
+	won := make(map[string]model.CampaignMatch, len(result.matches))
+	for _, m := range result.matches {
+		won[m.ID] = m
+	}
+
+	candidates := make([]model.CandidateExplanation, len(result.ranked))
+	for i, c := range result.ranked {
+		explanation := model.CandidateExplanation{
+			CampaignMatch: model.CampaignMatch{
+				ID:           c.ID,
+				Bid:          c.Bid,
+				EffectiveBid: c.EffectiveBid,
+				PricingModel: c.PricingModel,
+				Priority:     c.Priority,
+			},
+			ECPM: c.ECPM,
+			Rank: i + 1,
+		}
+
+		verdict, seen := result.verdicts[c.ID]
+		if m, ok := won[c.ID]; ok {
+			explanation.CampaignMatch = m
+			verdict = model.Won
+		} else if !seen {
+			// only the campaigns of the deals competed
+			verdict = model.NotInDeal
+		}
+		explanation.Verdict = verdict
+		candidates[i] = explanation
+	}
+
+	explanation := model.Explanation{Floor: result.floor, Candidates: candidates}
+	if len(result.winners) == 0 {
+		explanation.NoMatchReason = result.reason
+	}
+	return explanation, nil
+}
+
+// auctionResult is the outcome of the auction of a delivery request, before anything is delivered.
+type auctionResult struct {
+	floor  decimal.Decimal
+	now    time.Time
+	ranked []model.Candidate
+	// verdicts holds why the candidates that competed were not selected, by campaign ID.
+	verdicts map[string]model.Verdict
+	// winners holds the candidates selected for the slots, and matches their clearing price.
+	winners []model.Candidate
+	matches []model.CampaignMatch
+	reason  model.NoMatchReason
+}
+
+// runAuction ranks the candidates of the request and selects the winners of its slots. Explained
+// auctions peek at the ranking strategy, so they do not change the ranking of the next deliveries.
+func (s *Service) runAuction(ctx context.Context, req model.MatchRequest, explain bool) (auctionResult, error) {
+	candidates, err := s.campaignRepository.FindCandidates(ctx, req.Targeting, req.Formats, req.Sizes)
+	if err != nil {
+		return auctionResult{}, err
+	}
+
+	floor, err := s.campaignRepository.FindFloor(ctx, req.Targeting)
+	if err != nil {
+		return auctionResult{}, err
+	}
+	floor = decimal.Max(floor, req.BidFloor)
+
+	exposures := model.Exposures{}
+	if req.UserID != "" {
+		exposures, err = s.exposureRepository.FindExposures(ctx, req.UserID)
+		if err != nil {
+			return auctionResult{}, err
+		}
+	}
+
+	pageView := model.PageView{}
+	if req.PageViewID != "" {
+		pageView, err = s.exposureRepository.FindPageView(ctx, req.PageViewID)
+		if err != nil {
+			return auctionResult{}, err
+		}
+	}
+
+	now := s.now()
+	rules := eligibility{floor: floor, formats: req.Formats, sizes: req.Sizes, exposures: exposures,
+		pageView: pageView, now: now, rand: s.rand}
+	ranked := s.rank(candidates, req.Targeting, now, explain)
+	slots := max(req.Slots, 1)
+
+	// the campaigns of the deals allowing the publisher compete first, with the deal price as floor
+	// and clearing price, and the open auction is only held when none of them can be delivered,
+	// unless the request is a private auction
+	var selection selection
+	if len(req.DealIDs) > 0 {
+		deals, err := s.campaignRepository.FindDeals(ctx, req.DealIDs)
+		if err != nil {
+			return auctionResult{}, err
+		}
+		selection = selectWinners(inDeals(ranked, deals, req.Publisher), rules, req.UniqueAdvertisers, slots)
+		allowed := slices.ContainsFunc(deals, func(d model.Deal) bool { return d.Allows(req.Publisher) })
+		if !allowed && req.PrivateAuction {
+			selection.reason = model.NoDeal
+		}
+	}
+	if len(selection.winners) == 0 && (len(req.DealIDs) == 0 || !req.PrivateAuction) {
+		selection = selectWinners(ranked, rules, req.UniqueAdvertisers, slots)
+	}
+
+	result := auctionResult{floor: floor, now: now, ranked: ranked, verdicts: selection.verdicts}
+	if len(selection.winners) == 0 {
+		result.reason = selection.reason
+		return result, nil
+	}
+
+	result.winners = selection.winners
+	result.matches = make([]model.CampaignMatch, 0, len(result.winners))
+	for _, c := range result.winners {
+		price := s.clearingPrice(c, selection.runnerUp(c), floor)
+		creative, _ := c.CreativeFor(req.Formats, req.Sizes)
+		result.matches = append(result.matches, model.CampaignMatch{
+			ID:            c.ID,
+			Bid:           c.Bid,
+			EffectiveBid:  c.EffectiveBid,
+			PricingModel:  c.PricingModel,
+			Priority:      c.Priority,
+			ClearingPrice: price,
+			ClearingCPM:   clearingCPM(c, price),
+			DealID:        c.Deal.ID,
+			Creative:      creative,
+		})
+	}
+	return result, nil
+}
+
+// rank sets the effective bid of the candidates for the targeting and the hour of now,
+// and their effective CPM from their predicted rates, so campaigns of different pricing models
+// compete on the value of their deliveries. It then orders them by priority tier, guaranteed
+// campaigns before standard ones and house campaigns last, and within each tier with the ranking
+// strategy of the country, peeking at it when told to.
+func (s *Service) rank(candidates []model.Candidate, targeting model.Targeting, now time.Time, peekOnly bool) []model.Candidate {
+	hour := now.Hour()
+	tiers := map[int][]model.Candidate{}
+	for _, c := range candidates {
+		c.EffectiveBid = c.Bid.Mul(c.BidModifiers.Multiplier(targeting, hour))
+		c.ECPM = c.EffectiveCPM(s.predictor.Predict(c))
+		tier := c.Priority.Tier()
+		tiers[tier] = append(tiers[tier], c)
+	}
+
+	strategy := s.strategyFor(targeting.Country)
+	ranked := make([]model.Candidate, 0, len(candidates))
+	for _, tier := range slices.Sorted(maps.Keys(tiers)) {
+		if peekOnly {
+			ranked = append(ranked, peek(strategy, tiers[tier])...)
+		} else {
+			ranked = append(ranked, strategy.Order(tiers[tier])...)
+		}
+	}
+	return ranked
+}
+
+// strategyFor returns the ranking strategy of the country, or the deployment one.
+func (s *Service) strategyFor(country model.Country) Strategy {
+	if strategy, ok := s.countryStrategies[country]; ok {
+		return strategy
+	}
+	return s.strategy
+}
+
+// eligibility holds the rules a candidate must satisfy to be delivered.
+type eligibility struct {
+	floor decimal.Decimal
+	// formats and sizes are the formats and sizes accepted by the slot, which the candidates
+	// must have an approved creative fitting, banners of any size when none is given.
+	formats   []model.CreativeFormat
+	sizes     []model.Size
+	exposures model.Exposures
+	// pageView holds the categories delivered by the previous requests of the page view.
+	pageView model.PageView
+	now      time.Time
+	// rand draws whether impression goal campaigns ahead of schedule are selected.
+	rand func() float64
+}
+
+// check returns why the candidate cannot be delivered, or an empty reason when it is eligible.
+func (e eligibility) check(c model.Candidate) model.NoMatchReason {
+	if !c.Active {
+		return model.NoActiveCampaign
+	}
+	if _, ok := c.CreativeFor(e.formats, e.sizes); !ok {
+		return model.NoCreative
+	}
+	floor := e.floor
+	if c.Deal.ID != "" {
+		floor = c.Deal.Price
+	}
+	// house campaigns are not sold, so they fill the slots whatever the floor
+	if c.Priority != model.House && c.ECPM.Shift(-3).LessThan(floor) {
+		return model.BelowFloor
+	}
+	if c.FrequencyCap.Reached(c.ID, e.exposures, e.now) {
+		return model.FrequencyCapped
+	}
+	if e.exposures.RecencyCapped(c.ID, c.RecencyCap, e.now) {
+		return model.RecencyCapped
+	}
+	if !c.Sequence.Allows(e.exposures, e.now) {
+		return model.OutOfSequence
+	}
+	// impression goal campaigns ahead of their expected deliveries are skipped more and more
+	// often, spreading their deliveries until their expiration
+	if p := c.PacingProbability(e.now); p < 1 && e.rand() >= p {
+		return model.Paced
+	}
+	return ""
+}
+
+// inDeals returns the ranked candidates attached to the deals allowing the publisher,
+// each competing through the first of these deals it is attached to.
+func inDeals(ranked []model.Candidate, deals []model.Deal, publisher string) []model.Candidate {
+	var candidates []model.Candidate
+	for _, c := range ranked {
+		for _, deal := range deals {
+			if deal.Allows(publisher) && deal.Includes(c.ID) {
+				c.Deal = deal
+				candidates = append(candidates, c)
+				break
+			}
+		}
+	}
+	return candidates
+}
+
+// selection holds the winners of the slots among the ranked candidates.
+type selection struct {
+	winners []model.Candidate
+	// contenders holds the candidates passing the eligibility rules in ranking order, winners included,
+	// whatever the categories of the winners: any of them may set the price of a winner. The candidates
+	// of the advertiser of a winner are left out when the advertisers are unique.
+	contenders []model.Candidate
+	// verdicts holds why the candidates that competed were not selected, by campaign ID.
+	verdicts map[string]model.Verdict
+	// reason is why nothing was selected, empty when there are winners.
+	reason model.NoMatchReason
+}
+
+// selectWinners returns up to slots ranked candidates passing the eligibility rules, and the verdict
+// of the others: why they were skipped, or outranked when passing them once the slots are filled.
+// Candidates competing with the category of a winner, or delivered earlier in the page view, are
+// skipped. When uniqueAdvertisers is set, only the best candidate of each advertiser wins. If no
+// candidate is eligible, the reason of the best ranked active candidate is returned instead.
+func selectWinners(ranked []model.Candidate, rules eligibility, uniqueAdvertisers bool, slots int) selection {
+	s := selection{
+		winners:  make([]model.Candidate, 0, slots),
+		verdicts: make(map[string]model.Verdict, len(ranked)),
+		reason:   model.NoActiveCampaign,
+	}
+	advertisers := map[string]bool{}
+	pageView := maps.Clone(rules.pageView)
+	if pageView == nil {
+		pageView = model.PageView{}
+	}
+
+	for _, c := range ranked {
+		if r := rules.check(c); r != "" {
+			if r == model.NoActiveCampaign {
+				s.verdicts[c.ID] = c.InactiveVerdict(rules.now)
+				continue
+			}
+			if s.reason == model.NoActiveCampaign {
+				s.reason = r
+			}
+			s.verdicts[c.ID] = model.Verdict(r)
+			continue
+		}
+		if uniqueAdvertisers && c.Advertiser != "" && advertisers[c.Advertiser] {
+			s.verdicts[c.ID] = model.DuplicateAdvertiser
+			continue
+		}
+		s.contenders = append(s.contenders, c)
+		if len(s.winners) == slots {
+			s.verdicts[c.ID] = model.Outranked
+			continue
+		}
+		if pageView.Competes(c.Campaign) {
+			if s.reason == model.NoActiveCampaign {
+				s.reason = model.CompetingCategory
+			}
+			s.verdicts[c.ID] = model.Verdict(model.CompetingCategory)
+			continue
+		}
+		advertisers[c.Advertiser] = true
+		pageView.Add(c.Campaign)
+		s.winners = append(s.winners, c)
+	}
+
+	if len(s.winners) > 0 {
+		s.reason = ""
+	}
+	return s
+}
+
+// runnerUp returns the contender ranked right after the winner in its priority tier, whose value
+// sets the second price of the winner, or nil when there is none.
+func (s selection) runnerUp(winner model.Candidate) *model.Candidate {
+	i := slices.IndexFunc(s.contenders, func(c model.Candidate) bool { return c.ID == winner.ID })
+	if i < 0 || i+1 == len(s.contenders) || s.contenders[i+1].Priority.Tier() != winner.Priority.Tier() {
+		return nil
+	}
+	return &s.contenders[i+1]
+}
+
+// clearingPrice returns the price the winner pays in its pricing model, up to its effective
+// bid. House campaigns pay nothing, campaigns delivered through a deal pay the deal price, and
+// guaranteed campaigns their effective bid.
+// The runner-up value, the floor, the deal price and the auction rules are expressed per delivery,
+// so they are converted to the winner bid units with the ratio between its bid and its value per delivery.
+func (s *Service) clearingPrice(winner model.Candidate, runnerUp *model.Candidate, floor decimal.Decimal) decimal.Decimal {
+	if winner.Priority == model.House {
+		return decimal.Zero
+	}
+
+	value := winner.ECPM.Shift(-3)
+	if !value.IsPositive() {
+		return winner.EffectiveBid
+	}
+	toBidUnits := func(v decimal.Decimal) decimal.Decimal {
+		return winner.EffectiveBid.Mul(v).Div(value)
+	}
+
+	if winner.Deal.ID != "" {
+		return toBidUnits(winner.Deal.Price)
+	}
+	if winner.Priority == model.Guaranteed {
+		return winner.EffectiveBid
+	}
+
+	var runnerUpBid *decimal.Decimal
+	if runnerUp != nil {
+		bid := toBidUnits(runnerUp.ECPM.Shift(-3))
+		runnerUpBid = &bid
+	}
+
+	auction := s.auction
+	auction.MinIncrement = toBidUnits(auction.MinIncrement)
+	auction.Floor = toBidUnits(auction.Floor)
+	return auction.ClearingPrice(winner.EffectiveBid, runnerUpBid, toBidUnits(floor))
+}
+
+// clearingCPM converts the clearing price of the winner, in its pricing model, to a price per thousand
+// deliveries with the ratio between its effective CPM and its effective bid.
+func clearingCPM(winner model.Candidate, price decimal.Decimal) decimal.Decimal {
+	if !winner.EffectiveBid.IsPositive() {
+		return decimal.Zero
+	}
+	return price.Mul(winner.ECPM).Div(winner.EffectiveBid)
+}
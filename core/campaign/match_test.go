@@ -0,0 +1,933 @@
+package campaign
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"ad-campaign-delivery/pkg"
+	"ad-campaign-delivery/ports_out"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignService_Match(t *testing.T) {
+	now := time.Now()
+	matchedAt := time.Date(2025, 6, 1, 20, 30, 0, 0, time.UTC)
+	targeting := model.Targeting{Country: model.France, Device: model.Mobile, OS: model.Android}
+	secondPrice := model.Auction{Type: model.SecondPrice, MinIncrement: decimal.NewFromFloat(0.01)}
+
+	approved := model.Review{State: model.Approved}
+	// banner is the creative of the candidates, unless given others, and of the matches expecting none
+	banner := model.Creative{ID: "banner", Format: model.Banner, Review: approved}
+	candidate := func(id string, active bool, bid float64) model.Candidate {
+		return model.Candidate{Campaign: model.Campaign{ID: id, Active: active,
+			Bid: decimal.NewFromFloat(bid), CreatedAt: now}, Creatives: []model.Creative{banner}}
+	}
+	withAdvertiser := func(c model.Candidate, advertiser string) model.Candidate {
+		c.Advertiser = advertiser
+		return c
+	}
+	inCategory := func(c model.Candidate, advertiser string, category model.Category) model.Candidate {
+		c.Advertiser = advertiser
+		c.Category = category
+		return c
+	}
+	withPriority := func(c model.Candidate, priority model.Priority) model.Candidate {
+		c.Priority = priority
+		return c
+	}
+	// withGoal sells the goal over ten days, half of them elapsed when matched
+	withGoal := func(c model.Candidate, goal, deliveries int64) model.Candidate {
+		c.Priority = model.Guaranteed
+		c.ImpressionGoal = goal
+		c.Stats.Deliveries = deliveries
+		c.CreatedAt = matchedAt.AddDate(0, 0, -5)
+		c.ExpiresAt = matchedAt.AddDate(0, 0, 5)
+		return c
+	}
+	withCreatives := func(c model.Candidate, creatives ...model.Creative) model.Candidate {
+		c.Creatives = creatives
+		return c
+	}
+	medium := model.Creative{ID: "medium", Format: model.Banner, Size: model.Size{Width: 300, Height: 250},
+		HTML: "<div>medium</div>", Review: approved}
+	leaderboard := model.Creative{ID: "leaderboard", Format: model.Banner, Size: model.Size{Width: 728, Height: 90},
+		ImageURL: "https://cdn.example.com/leaderboard.png", Review: approved}
+	preroll := model.Creative{ID: "preroll", Format: model.Video, Video: model.LinearVideo{Duration: 15 * time.Second,
+		MediaFiles: []model.MediaFile{{URL: "https://cdn.example.com/preroll.mp4", MIMEType: "video/mp4"}}},
+		Review: approved}
+	infeed := model.Creative{ID: "infeed", Format: model.Native, Native: model.NativeAd{Title: "Summer sale",
+		Image:       model.NativeImage{URL: "https://cdn.example.com/sale.png", Size: model.Size{Width: 1200, Height: 627}},
+		SponsoredBy: "Example"}, Review: approved}
+	pending := model.Creative{ID: "pending", Format: model.Banner, Size: model.Size{Width: 300, Height: 250},
+		ImageURL: "https://cdn.example.com/pending.png", Review: model.Review{State: model.Pending}}
+	rejected := model.Creative{ID: "rejected", Format: model.Banner, Size: model.Size{Width: 300, Height: 250},
+		ImageURL: "https://cdn.example.com/rejected.png", Review: model.Review{State: model.Rejected, Reason: "misleading claim"}}
+	withSlots := func(c model.Candidate, formats []model.CreativeFormat, sizes ...model.Size) model.Candidate {
+		c.Formats = formats
+		c.Sizes = sizes
+		return c
+	}
+	withCap := func(c model.Candidate, impressions int, period time.Duration) model.Candidate {
+		c.FrequencyCap = model.FrequencyCap{Impressions: impressions, Period: period}
+		return c
+	}
+
+	tests := []struct {
+		name           string
+		auction        model.Auction
+		strategies     map[model.Country]Strategy
+		candidates     []model.Candidate
+		findErr        error
+		floorRule      decimal.Decimal
+		deals          []model.Deal
+		req            model.MatchRequest
+		deliverErrs    []error
+		exposures      model.Exposures
+		pageView       model.PageView
+		wantMatches    []model.CampaignMatch
+		wantExposed    []string
+		wantPageView   model.PageView
+		wantDeliveries []model.Delivery
+		wantReason     model.NoMatchReason
+		wantErr        error
+	}{
+		{
+			name: "skips the first inactive higher bid, returns second bid, deducts the bid",
+			candidates: []model.Candidate{
+				candidate("1", false, 100),
+				candidate("2", true, 5),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(5), ClearingPrice: decimal.NewFromFloat(5)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(5)}},
+		},
+		{
+			name:    "second price auction, winner pays the next active bid plus the increment",
+			auction: secondPrice,
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				candidate("2", false, 8),
+				candidate("3", true, 4),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(4.01)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.NewFromFloat(4.01)}},
+		},
+		{
+			name: "second price auction, winner without runner-up pays the floor",
+			auction: model.Auction{Type: model.SecondPrice, MinIncrement: decimal.NewFromFloat(0.01),
+				Floor: decimal.NewFromFloat(0.5)},
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(0.5)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.NewFromFloat(0.5)}},
+		},
+		{
+			name:    "second price auction, older campaign wins tied bids at its bid",
+			auction: secondPrice,
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				{Campaign: model.Campaign{ID: "2", Active: true, Bid: decimal.NewFromFloat(10),
+					CreatedAt: now.Add(-time.Hour)}, Creatives: []model.Creative{banner}},
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(10)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(10)}},
+		},
+		{
+			name:    "request bid floor skips lower bids, second price clears at the floor",
+			auction: secondPrice,
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				candidate("2", true, 4),
+			},
+			req: model.MatchRequest{BidFloor: decimal.NewFromFloat(6)},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(6)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.NewFromFloat(6)}},
+		},
+		{
+			name: "floor rule higher than the request floor, no bid clears it",
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+			},
+			floorRule:  decimal.NewFromFloat(20),
+			req:        model.MatchRequest{BidFloor: decimal.NewFromFloat(1)},
+			wantReason: model.BelowFloor,
+		},
+		{
+			name: "only inactive campaigns",
+			candidates: []model.Candidate{
+				candidate("1", false, 10),
+			},
+			wantReason: model.NoActiveCampaign,
+		},
+		{
+			name: "multiple slots, second price per slot, skips the repeated advertiser",
+			auction: model.Auction{Type: model.SecondPrice, MinIncrement: decimal.NewFromFloat(0.01),
+				Floor: decimal.NewFromFloat(0.5)},
+			candidates: []model.Candidate{
+				withAdvertiser(candidate("1", true, 10), "acme"),
+				withAdvertiser(candidate("2", true, 9), "acme"),
+				withAdvertiser(candidate("3", true, 6), "globex"),
+				candidate("4", true, 2),
+			},
+			req: model.MatchRequest{Slots: 3, UniqueAdvertisers: true},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(6.01)},
+				{ID: "3", Bid: decimal.NewFromFloat(6), ClearingPrice: decimal.NewFromFloat(2.01)},
+				{ID: "4", Bid: decimal.NewFromFloat(2), ClearingPrice: decimal.NewFromFloat(0.5)},
+			},
+			wantDeliveries: []model.Delivery{
+				{CampaignID: "1", Cost: decimal.NewFromFloat(6.01)},
+				{CampaignID: "3", Cost: decimal.NewFromFloat(2.01)},
+				{CampaignID: "4", Cost: decimal.NewFromFloat(0.5)},
+			},
+		},
+		{
+			name: "more slots than eligible campaigns",
+			candidates: []model.Candidate{
+				withAdvertiser(candidate("1", true, 10), "acme"),
+				withAdvertiser(candidate("2", true, 9), "acme"),
+			},
+			req: model.MatchRequest{Slots: 5},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(10)},
+				{ID: "2", Bid: decimal.NewFromFloat(9), ClearingPrice: decimal.NewFromFloat(9)},
+			},
+			wantDeliveries: []model.Delivery{
+				{CampaignID: "1", Cost: decimal.NewFromFloat(10)},
+				{CampaignID: "2", Cost: decimal.NewFromFloat(9)},
+			},
+		},
+		{
+			name:    "ranks by effective cpm, converting the second price to each pricing model",
+			auction: model.Auction{Type: model.SecondPrice},
+			candidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "cpm", Active: true, Bid: decimal.NewFromFloat(3),
+					PricingModel: model.CPM, CreatedAt: now}, Creatives: []model.Creative{banner}},
+				{Campaign: model.Campaign{ID: "cpc", Active: true, Bid: decimal.NewFromFloat(0.5),
+					PricingModel: model.CPC, CreatedAt: now},
+					Stats: model.DeliveryStats{Deliveries: 1000, Clicks: 10}, Creatives: []model.Creative{banner}},
+				{Campaign: model.Campaign{ID: "cpd", Active: true, Bid: decimal.NewFromFloat(0.002),
+					PricingModel: model.CPD, CreatedAt: now}, Creatives: []model.Creative{banner}},
+			},
+			req: model.MatchRequest{Slots: 2},
+			// effective cpm: cpc 0.5 * 1% * 1000 = 5, cpm 3, cpd 0.002 * 1000 = 2
+			wantMatches: []model.CampaignMatch{
+				{ID: "cpc", Bid: decimal.NewFromFloat(0.5), PricingModel: model.CPC,
+					ClearingPrice: decimal.NewFromFloat(0.3), ClearingCPM: decimal.NewFromFloat(3)},
+				{ID: "cpm", Bid: decimal.NewFromFloat(3), PricingModel: model.CPM,
+					ClearingPrice: decimal.NewFromFloat(2), ClearingCPM: decimal.NewFromFloat(2)},
+			},
+			wantDeliveries: []model.Delivery{
+				{CampaignID: "cpc", Cost: decimal.Zero},
+				{CampaignID: "cpm", Cost: decimal.NewFromFloat(0.002)},
+			},
+		},
+		{
+			name:    "bid modifiers of the targeting and hour adjust the ranking and the charge",
+			auction: secondPrice,
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				{Campaign: model.Campaign{ID: "2", Active: true, Bid: decimal.NewFromFloat(8), CreatedAt: now,
+					BidModifiers: model.BidModifiers{
+						OS:     map[model.OS]decimal.Decimal{model.Android: decimal.NewFromFloat(1.5), model.Mac: decimal.NewFromFloat(3)},
+						Hour:   map[int]decimal.Decimal{20: decimal.NewFromFloat(1.1), 8: decimal.NewFromFloat(0.1)},
+						Device: map[model.Device]decimal.Decimal{model.Desktop: decimal.NewFromFloat(0.5)},
+					}}, Creatives: []model.Creative{banner}},
+			},
+			// 8 * 1.5 (android) * 1.1 (20h) = 13.2
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(8), EffectiveBid: decimal.NewFromFloat(13.2),
+					ClearingPrice: decimal.NewFromFloat(10.01)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(10.01)}},
+		},
+		{
+			name:    "bid modifiers lowering the bid cap the charge at the effective bid",
+			auction: secondPrice,
+			candidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(10), CreatedAt: now,
+					BidModifiers: model.BidModifiers{
+						Country: map[model.Country]decimal.Decimal{model.France: decimal.NewFromFloat(0.6)},
+					}}, Creatives: []model.Creative{banner}},
+				candidate("2", true, 6),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), EffectiveBid: decimal.NewFromFloat(6),
+					ClearingPrice: decimal.NewFromFloat(6)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.NewFromFloat(6)}},
+		},
+		{
+			name: "skips the campaign that reached its frequency cap for the user",
+			candidates: []model.Candidate{
+				withCap(candidate("1", true, 10), 2, 24*time.Hour),
+				withCap(candidate("2", true, 5), 2, 24*time.Hour),
+			},
+			req: model.MatchRequest{UserID: "user1"},
+			exposures: model.Exposures{
+				"1": {matchedAt.Add(-3 * time.Hour), matchedAt.Add(-time.Hour)},
+				"2": {matchedAt.Add(-time.Hour)},
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(5), ClearingPrice: decimal.NewFromFloat(5)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(5)}},
+			wantExposed:    []string{"2"},
+		},
+		{
+			name: "deliveries older than the cap period do not count",
+			candidates: []model.Candidate{
+				withCap(candidate("1", true, 10), 2, 24*time.Hour),
+			},
+			req: model.MatchRequest{UserID: "user1", Slots: 2},
+			exposures: model.Exposures{
+				"1": {matchedAt.Add(-25 * time.Hour), matchedAt.Add(-time.Hour)},
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(10)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.NewFromFloat(10)}},
+			wantExposed:    []string{"1"},
+		},
+		{
+			name: "all active campaigns capped for the user",
+			candidates: []model.Candidate{
+				candidate("1", false, 20),
+				withCap(candidate("2", true, 10), 1, time.Hour),
+			},
+			req:        model.MatchRequest{UserID: "user1"},
+			exposures:  model.Exposures{"2": {matchedAt.Add(-time.Minute)}},
+			wantReason: model.FrequencyCapped,
+		},
+		{
+			name: "skips the campaign delivered to the user more recently than its recency cap",
+			candidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(10), CreatedAt: now,
+					RecencyCap: 10 * time.Minute}, Creatives: []model.Creative{banner}},
+				{Campaign: model.Campaign{ID: "2", Active: true, Bid: decimal.NewFromFloat(5), CreatedAt: now,
+					RecencyCap: 10 * time.Minute}, Creatives: []model.Creative{banner}},
+			},
+			req: model.MatchRequest{UserID: "user1"},
+			exposures: model.Exposures{
+				"1": {matchedAt.Add(-5 * time.Minute)},
+				"2": {matchedAt.Add(-10 * time.Minute)},
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(5), ClearingPrice: decimal.NewFromFloat(5)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(5)}},
+			wantExposed:    []string{"2"},
+		},
+		{
+			name: "all active campaigns recency capped for the user",
+			candidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(10), CreatedAt: now,
+					RecencyCap: time.Hour}, Creatives: []model.Creative{banner}},
+			},
+			req:        model.MatchRequest{UserID: "user1"},
+			exposures:  model.Exposures{"1": {matchedAt.Add(-time.Minute)}},
+			wantReason: model.RecencyCapped,
+		},
+		{
+			name: "delivers the next chapter of the story once the user saw the previous one",
+			candidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "chapter3", Active: true, Bid: decimal.NewFromFloat(30), CreatedAt: now,
+					Sequence: model.Sequence{After: "chapter2"}}, Creatives: []model.Creative{banner}},
+				{Campaign: model.Campaign{ID: "chapter2", Active: true, Bid: decimal.NewFromFloat(20), CreatedAt: now,
+					Sequence: model.Sequence{After: "chapter1", MinDelay: time.Hour}}, Creatives: []model.Creative{banner}},
+				{Campaign: model.Campaign{ID: "chapter1", Active: true, Bid: decimal.NewFromFloat(10), CreatedAt: now,
+					RecencyCap: 24 * time.Hour}, Creatives: []model.Creative{banner}},
+			},
+			req:       model.MatchRequest{UserID: "user1"},
+			exposures: model.Exposures{"chapter1": {matchedAt.Add(-2 * time.Hour)}},
+			wantMatches: []model.CampaignMatch{
+				{ID: "chapter2", Bid: decimal.NewFromFloat(20), ClearingPrice: decimal.NewFromFloat(20)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "chapter2", Cost: decimal.NewFromFloat(20)}},
+			wantExposed:    []string{"chapter2"},
+		},
+		{
+			name: "waits the minimum delay before the next chapter",
+			candidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "chapter2", Active: true, Bid: decimal.NewFromFloat(20), CreatedAt: now,
+					Sequence: model.Sequence{After: "chapter1", MinDelay: time.Hour}}, Creatives: []model.Creative{banner}},
+				{Campaign: model.Campaign{ID: "chapter1", Active: true, Bid: decimal.NewFromFloat(10), CreatedAt: now,
+					RecencyCap: 24 * time.Hour}, Creatives: []model.Creative{banner}},
+			},
+			req:        model.MatchRequest{UserID: "user1"},
+			exposures:  model.Exposures{"chapter1": {matchedAt.Add(-30 * time.Minute)}},
+			wantReason: model.OutOfSequence,
+		},
+		{
+			name: "sequenced campaigns are not delivered to unknown users",
+			candidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "chapter2", Active: true, Bid: decimal.NewFromFloat(20), CreatedAt: now,
+					Sequence: model.Sequence{After: "chapter1"}}, Creatives: []model.Creative{banner}},
+				candidate("other", true, 1),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "other", Bid: decimal.NewFromFloat(1), ClearingPrice: decimal.NewFromFloat(1)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "other", Cost: decimal.NewFromFloat(1)}},
+		},
+		{
+			name: "multiple slots exclude the competitors of the category of a winner",
+			candidates: []model.Candidate{
+				inCategory(candidate("1", true, 10), "acme", "automotive"),
+				inCategory(candidate("2", true, 9), "globex", "automotive"),
+				inCategory(candidate("3", true, 8), "acme", "automotive"),
+				inCategory(candidate("4", true, 5), "initech", "travel"),
+				candidate("5", true, 1),
+			},
+			req: model.MatchRequest{Slots: 3},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(10)},
+				{ID: "3", Bid: decimal.NewFromFloat(8), ClearingPrice: decimal.NewFromFloat(8)},
+				{ID: "4", Bid: decimal.NewFromFloat(5), ClearingPrice: decimal.NewFromFloat(5)},
+			},
+			wantDeliveries: []model.Delivery{
+				{CampaignID: "1", Cost: decimal.NewFromFloat(10)},
+				{CampaignID: "3", Cost: decimal.NewFromFloat(8)},
+				{CampaignID: "4", Cost: decimal.NewFromFloat(5)},
+			},
+		},
+		{
+			name:    "the competitor of the category of the winner sets its second price",
+			auction: secondPrice,
+			candidates: []model.Candidate{
+				inCategory(candidate("1", true, 10), "acme", "automotive"),
+				inCategory(candidate("2", true, 9), "globex", "automotive"),
+				candidate("3", true, 1),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(9.01)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.NewFromFloat(9.01)}},
+		},
+		{
+			name: "excludes the competitors of the categories delivered earlier in the page view",
+			candidates: []model.Candidate{
+				inCategory(candidate("1", true, 10), "globex", "automotive"),
+				inCategory(candidate("2", true, 5), "initech", "travel"),
+			},
+			req:      model.MatchRequest{PageViewID: "view1"},
+			pageView: model.PageView{"automotive": "acme"},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(5), ClearingPrice: decimal.NewFromFloat(5)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(5)}},
+			wantPageView:   model.PageView{"travel": "initech"},
+		},
+		{
+			name: "all active campaigns compete with the page view",
+			candidates: []model.Candidate{
+				inCategory(candidate("1", true, 10), "globex", "automotive"),
+			},
+			req:        model.MatchRequest{PageViewID: "view1"},
+			pageView:   model.PageView{"automotive": "acme"},
+			wantReason: model.CompetingCategory,
+		},
+		{
+			name:    "guaranteed campaign wins before higher standard bids and pays its bid",
+			auction: secondPrice,
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				withPriority(candidate("2", true, 3), model.Guaranteed),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(3), Priority: model.Guaranteed,
+					ClearingPrice: decimal.NewFromFloat(3)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(3)}},
+		},
+		{
+			name: "multiple slots by tier, standard winners priced against standard candidates only",
+			auction: model.Auction{Type: model.SecondPrice, MinIncrement: decimal.NewFromFloat(0.01),
+				Floor: decimal.NewFromFloat(0.5)},
+			candidates: []model.Candidate{
+				withPriority(candidate("house", true, 50), model.House),
+				candidate("1", true, 10),
+				withPriority(candidate("2", true, 3), model.Guaranteed),
+				candidate("3", true, 6),
+			},
+			req: model.MatchRequest{Slots: 3},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(3), Priority: model.Guaranteed,
+					ClearingPrice: decimal.NewFromFloat(3)},
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(6.01)},
+				{ID: "3", Bid: decimal.NewFromFloat(6), ClearingPrice: decimal.NewFromFloat(0.5)},
+			},
+			wantDeliveries: []model.Delivery{
+				{CampaignID: "2", Cost: decimal.NewFromFloat(3)},
+				{CampaignID: "1", Cost: decimal.NewFromFloat(6.01)},
+				{CampaignID: "3", Cost: decimal.NewFromFloat(0.5)},
+			},
+		},
+		{
+			name: "house campaign fills the slots left for free",
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				withPriority(candidate("house", true, 1), model.House),
+			},
+			req: model.MatchRequest{Slots: 3},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(10)},
+				{ID: "house", Bid: decimal.NewFromFloat(1), Priority: model.House, ClearingPrice: decimal.Zero},
+			},
+			wantDeliveries: []model.Delivery{
+				{CampaignID: "1", Cost: decimal.NewFromFloat(10)},
+				{CampaignID: "house", Cost: decimal.Zero},
+			},
+		},
+		{
+			name: "house campaign is delivered instead of no match, whatever the floor",
+			candidates: []model.Candidate{
+				candidate("1", false, 10),
+				candidate("2", true, 3),
+				withPriority(candidate("house", true, 1), model.House),
+			},
+			req: model.MatchRequest{BidFloor: decimal.NewFromFloat(5)},
+			wantMatches: []model.CampaignMatch{
+				{ID: "house", Bid: decimal.NewFromFloat(1), Priority: model.House, ClearingPrice: decimal.Zero},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "house", Cost: decimal.Zero}},
+		},
+		{
+			name: "impression goal campaign behind schedule is delivered for free",
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				withGoal(candidate("goal", true, 2), 1000, 400),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "goal", Bid: decimal.NewFromFloat(2), Priority: model.Guaranteed,
+					ClearingPrice: decimal.NewFromFloat(2)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "goal", Cost: decimal.Zero}},
+		},
+		{
+			name: "impression goal campaign slightly ahead of schedule is still delivered",
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				withGoal(candidate("goal", true, 2), 1000, 520),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "goal", Bid: decimal.NewFromFloat(2), Priority: model.Guaranteed,
+					ClearingPrice: decimal.NewFromFloat(2)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "goal", Cost: decimal.Zero}},
+		},
+		{
+			name: "impression goal campaign further ahead of schedule is paced",
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				withGoal(candidate("goal", true, 2), 1000, 530),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(10)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.NewFromFloat(10)}},
+		},
+		{
+			name: "only impression goal campaigns ahead of schedule",
+			candidates: []model.Candidate{
+				withGoal(candidate("goal", true, 2), 1000, 600),
+			},
+			wantReason: model.Paced,
+		},
+		{
+			name: "deal campaign wins over higher open bids at the deal price",
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				candidate("2", true, 3),
+			},
+			deals: []model.Deal{{ID: "deal1", Price: decimal.NewFromFloat(2), Campaigns: []string{"2"}}},
+			req:   model.MatchRequest{Publisher: "pub1", DealIDs: []string{"deal1"}},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(3), ClearingPrice: decimal.NewFromFloat(2), DealID: "deal1"},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(2)}},
+		},
+		{
+			name: "deal price converted to the pricing model of the campaign",
+			candidates: []model.Candidate{
+				{Campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(4),
+					PricingModel: model.CPM, CreatedAt: now}, Creatives: []model.Creative{banner}},
+			},
+			deals: []model.Deal{{ID: "deal1", Price: decimal.RequireFromString("0.003"), Campaigns: []string{"1"}}},
+			req:   model.MatchRequest{Publisher: "pub1", DealIDs: []string{"deal1"}},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(4), PricingModel: model.CPM,
+					ClearingPrice: decimal.NewFromFloat(3), DealID: "deal1"},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.RequireFromString("0.003")}},
+		},
+		{
+			name: "falls back to the open auction when the deal does not allow the publisher",
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				candidate("2", true, 3),
+			},
+			deals: []model.Deal{{ID: "deal1", Price: decimal.NewFromFloat(2), Campaigns: []string{"2"},
+				Publishers: []string{"pub2"}}},
+			req: model.MatchRequest{Publisher: "pub1", DealIDs: []string{"deal1"}},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(10)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.NewFromFloat(10)}},
+		},
+		{
+			name: "private auction without deal campaign bidding the deal price",
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				candidate("2", true, 1),
+			},
+			deals:      []model.Deal{{ID: "deal1", Price: decimal.NewFromFloat(2), Campaigns: []string{"2"}}},
+			req:        model.MatchRequest{Publisher: "pub1", DealIDs: []string{"deal1"}, PrivateAuction: true},
+			wantReason: model.BelowFloor,
+		},
+		{
+			name:       "private auction without known deal",
+			candidates: []model.Candidate{candidate("1", true, 10)},
+			req:        model.MatchRequest{Publisher: "pub1", DealIDs: []string{"expired"}, PrivateAuction: true},
+			wantReason: model.NoDeal,
+		},
+		{
+			name:       "private auction without deal allowing the publisher",
+			candidates: []model.Candidate{candidate("1", true, 10)},
+			deals: []model.Deal{{ID: "deal1", Price: decimal.NewFromFloat(2), Campaigns: []string{"1"},
+				Publishers: []string{"pub2"}}},
+			req:        model.MatchRequest{Publisher: "pub1", DealIDs: []string{"deal1"}, PrivateAuction: true},
+			wantReason: model.NoDeal,
+		},
+		{
+			name: "skips the campaigns without a creative fitting the slot, delivered with the fitting creative",
+			candidates: []model.Candidate{
+				withCreatives(candidate("1", true, 10), medium),
+				candidate("2", true, 8),
+				withCreatives(candidate("3", true, 5), medium, leaderboard),
+			},
+			req: model.MatchRequest{Sizes: []model.Size{{Width: 728, Height: 90}, {Width: 970, Height: 90}}},
+			wantMatches: []model.CampaignMatch{
+				{ID: "3", Bid: decimal.NewFromFloat(5), ClearingPrice: decimal.NewFromFloat(5), Creative: leaderboard},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "3", Cost: decimal.NewFromFloat(5)}},
+		},
+		{
+			name: "delivered with the creative of the format of the slot",
+			candidates: []model.Candidate{
+				withCreatives(candidate("1", true, 10), medium),
+				withCreatives(candidate("2", true, 5), leaderboard, preroll),
+			},
+			req: model.MatchRequest{Formats: []model.CreativeFormat{model.Video}},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(5), ClearingPrice: decimal.NewFromFloat(5), Creative: preroll},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(5)}},
+		},
+		{
+			name: "slot sizes only fitting the banners of the formats of the slot",
+			candidates: []model.Candidate{
+				withCreatives(candidate("1", true, 10), medium),
+				withCreatives(candidate("2", true, 5), leaderboard, infeed),
+			},
+			req: model.MatchRequest{Formats: []model.CreativeFormat{model.Banner, model.Native},
+				Sizes: []model.Size{{Width: 970, Height: 250}}},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(5), ClearingPrice: decimal.NewFromFloat(5), Creative: infeed},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(5)}},
+		},
+		{
+			name: "only the creatives of the formats and sizes of the campaign delivered",
+			candidates: []model.Candidate{
+				withSlots(withCreatives(candidate("1", true, 10), medium, leaderboard), nil, leaderboard.Size),
+				withSlots(withCreatives(candidate("2", true, 5), preroll, infeed), []model.CreativeFormat{model.Native}),
+			},
+			req: model.MatchRequest{Slots: 2, Formats: []model.CreativeFormat{model.Banner, model.Video, model.Native}},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(10), Creative: leaderboard},
+				{ID: "2", Bid: decimal.NewFromFloat(5), ClearingPrice: decimal.NewFromFloat(5), Creative: infeed},
+			},
+			wantDeliveries: []model.Delivery{
+				{CampaignID: "1", Cost: decimal.NewFromFloat(10)},
+				{CampaignID: "2", Cost: decimal.NewFromFloat(5)},
+			},
+		},
+		{
+			name: "first banner creative delivered without slot size",
+			candidates: []model.Candidate{
+				withCreatives(candidate("1", true, 10), preroll, medium, leaderboard),
+				candidate("2", true, 8),
+			},
+			req: model.MatchRequest{Slots: 2},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(10), Creative: medium},
+				{ID: "2", Bid: decimal.NewFromFloat(8), ClearingPrice: decimal.NewFromFloat(8)},
+			},
+			wantDeliveries: []model.Delivery{
+				{CampaignID: "1", Cost: decimal.NewFromFloat(10)},
+				{CampaignID: "2", Cost: decimal.NewFromFloat(8)},
+			},
+		},
+		{
+			name: "skips the campaigns without approved creatives, delivered with their first approved creative",
+			candidates: []model.Candidate{
+				withCreatives(candidate("1", true, 10), pending, rejected),
+				withCreatives(candidate("2", true, 8), pending, leaderboard),
+				candidate("3", true, 6),
+			},
+			req: model.MatchRequest{Slots: 2},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(8), ClearingPrice: decimal.NewFromFloat(8), Creative: leaderboard},
+				{ID: "3", Bid: decimal.NewFromFloat(6), ClearingPrice: decimal.NewFromFloat(6)},
+			},
+			wantDeliveries: []model.Delivery{
+				{CampaignID: "2", Cost: decimal.NewFromFloat(8)},
+				{CampaignID: "3", Cost: decimal.NewFromFloat(6)},
+			},
+		},
+		{
+			name:       "no approved creative",
+			candidates: []model.Candidate{withCreatives(candidate("1", true, 10), pending)},
+			req:        model.MatchRequest{},
+			wantReason: model.NoCreative,
+		},
+		{
+			name:       "no creative",
+			candidates: []model.Candidate{withCreatives(candidate("1", true, 10))},
+			req:        model.MatchRequest{},
+			wantReason: model.NoCreative,
+		},
+		{
+			name:       "no banner when no format is requested",
+			candidates: []model.Candidate{withCreatives(candidate("1", true, 10), preroll)},
+			req:        model.MatchRequest{},
+			wantReason: model.NoCreative,
+		},
+		{
+			name: "no creative fitting the slot",
+			candidates: []model.Candidate{
+				withCreatives(candidate("1", true, 10), medium),
+			},
+			req:        model.MatchRequest{Sizes: []model.Size{{Width: 728, Height: 90}}},
+			wantReason: model.NoCreative,
+		},
+		{
+			name:       "uses the ranking strategy of the country",
+			strategies: map[model.Country]Strategy{model.France: reverseStrategy{}},
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+				candidate("2", true, 5),
+			},
+			wantMatches: []model.CampaignMatch{
+				{ID: "2", Bid: decimal.NewFromFloat(5), ClearingPrice: decimal.NewFromFloat(5)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "2", Cost: decimal.NewFromFloat(5)}},
+		},
+		{
+			name: "retries when a winner can no longer be delivered",
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+			},
+			deliverErrs: []error{pkg.Errorf(pkg.ECONFLICT, "campaign with ID 1 can no longer be delivered")},
+			wantMatches: []model.CampaignMatch{
+				{ID: "1", Bid: decimal.NewFromFloat(10), ClearingPrice: decimal.NewFromFloat(10)},
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.NewFromFloat(10)}},
+		},
+		{
+			name: "gives up after the maximum attempts",
+			candidates: []model.Candidate{
+				candidate("1", true, 10),
+			},
+			deliverErrs: []error{
+				pkg.Errorf(pkg.ECONFLICT, "campaign with ID 1 can no longer be delivered"),
+				pkg.Errorf(pkg.ECONFLICT, "campaign with ID 1 can no longer be delivered"),
+				pkg.Errorf(pkg.ECONFLICT, "campaign with ID 1 can no longer be delivered"),
+			},
+			wantDeliveries: []model.Delivery{{CampaignID: "1", Cost: decimal.NewFromFloat(10)}},
+			wantErr:        pkg.Errorf(pkg.ECONFLICT, "campaign with ID 1 can no longer be delivered"),
+		},
+		{
+			name:    "no campaign found",
+			findErr: pkg.Errorf(pkg.ENOTFOUND, "no campaign found for FR, mobile, android"),
+			wantErr: pkg.Errorf(pkg.ENOTFOUND, "no campaign found for FR, mobile, android"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deliveries []model.Delivery
+			campaignRepo := &ports_out.CampaignRepositoryMock{
+				FindCandidatesFunc: func(ctx context.Context, tg model.Targeting, formats []model.CreativeFormat, sizes []model.Size) ([]model.Candidate, error) {
+					assert.Equal(t, targeting, tg)
+					return tt.candidates, tt.findErr
+				},
+				FindFloorFunc: func(ctx context.Context, tg model.Targeting) (decimal.Decimal, error) {
+					return tt.floorRule, nil
+				},
+				FindDealsFunc: func(ctx context.Context, ids []string) ([]model.Deal, error) {
+					assert.Equal(t, tt.req.DealIDs, ids)
+					return tt.deals, nil
+				},
+				DeliverCampaignsFunc: func(ctx context.Context, d []model.Delivery) error {
+					deliveries = d
+					if len(tt.deliverErrs) > 0 {
+						err := tt.deliverErrs[0]
+						tt.deliverErrs = tt.deliverErrs[1:]
+						return err
+					}
+					return nil
+				},
+			}
+
+			var exposed []string
+			var recordedPageView model.PageView
+			exposureRepo := &ports_out.ExposureRepositoryMock{
+				FindExposuresFunc: func(ctx context.Context, userID string) (model.Exposures, error) {
+					assert.Equal(t, "user1", userID)
+					return tt.exposures, nil
+				},
+				RecordExposuresFunc: func(ctx context.Context, userID string, campaignIDs []string, at time.Time) error {
+					assert.Equal(t, "user1", userID)
+					assert.Equal(t, matchedAt, at)
+					exposed = campaignIDs
+					return nil
+				},
+				FindPageViewFunc: func(ctx context.Context, pageViewID string) (model.PageView, error) {
+					assert.Equal(t, "view1", pageViewID)
+					return tt.pageView, nil
+				},
+				RecordPageViewFunc: func(ctx context.Context, pageViewID string, categories model.PageView, at time.Time) error {
+					assert.Equal(t, "view1", pageViewID)
+					assert.Equal(t, matchedAt, at)
+					recordedPageView = categories
+					return nil
+				},
+			}
+
+			service := NewService(campaignRepo, exposureRepo, Config{
+				Auction:           tt.auction,
+				Predictor:         HistoricalPredictor{},
+				CountryStrategies: tt.strategies,
+			})
+			service.now = func() time.Time {
+				return matchedAt
+			}
+			// goal campaigns are selected below a pacing probability of 0.5
+			service.rand = func() float64 {
+				return 0.5
+			}
+			reservations := 0
+			service.newID = func() string {
+				reservations++
+				return fmt.Sprintf("res%d", reservations)
+			}
+			req := tt.req
+			req.Targeting = targeting
+			result, err := service.Match(context.Background(), req)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantReason, result.NoMatchReason)
+
+			assert.Len(t, result.Matches, len(tt.wantMatches))
+			for i, want := range tt.wantMatches {
+				got := result.Matches[i]
+				assert.Equal(t, want.ID, got.ID)
+				assert.True(t, want.Bid.Equal(got.Bid))
+				effectiveBid := want.EffectiveBid
+				if effectiveBid.IsZero() {
+					effectiveBid = want.Bid
+				}
+				assert.True(t, effectiveBid.Equal(got.EffectiveBid),
+					"effective bid of %s is %s, want %s", got.ID, got.EffectiveBid, effectiveBid)
+				assert.Equal(t, want.PricingModel, got.PricingModel)
+				assert.Equal(t, want.Priority, got.Priority)
+				assert.Equal(t, want.DealID, got.DealID)
+				wantCreative := want.Creative
+				if wantCreative.ID == "" {
+					wantCreative = banner
+				}
+				assert.Equal(t, wantCreative, got.Creative)
+				assert.True(t, want.ClearingPrice.Equal(got.ClearingPrice),
+					"clearing price of %s is %s, want %s", got.ID, got.ClearingPrice, want.ClearingPrice)
+				if !want.ClearingCPM.IsZero() {
+					assert.True(t, want.ClearingCPM.Equal(got.ClearingCPM),
+						"clearing cpm of %s is %s, want %s", got.ID, got.ClearingCPM, want.ClearingCPM)
+				}
+			}
+
+			assert.Len(t, deliveries, len(tt.wantDeliveries))
+			for i, want := range tt.wantDeliveries {
+				assert.Equal(t, want.CampaignID, deliveries[i].CampaignID)
+				assert.True(t, want.Cost.Equal(deliveries[i].Cost),
+					"cost of %s is %s, want %s", want.CampaignID, deliveries[i].Cost, want.Cost)
+				// the delivery is reserved until its impression
+				assert.NotEmpty(t, deliveries[i].ReservationID)
+				assert.Equal(t, matchedAt.Add(defaultReservationTTL), deliveries[i].ExpiresAt)
+				if i < len(result.Matches) {
+					assert.Equal(t, deliveries[i].ReservationID, result.Matches[i].ReservationID)
+				}
+			}
+
+			assert.Equal(t, tt.wantExposed, exposed)
+			assert.Equal(t, tt.wantPageView, recordedPageView)
+			if req.UserID == "" {
+				assert.Len(t, exposureRepo.FindExposuresCalls(), 0)
+			}
+		})
+	}
+}
+
+func TestCampaignService_Match_DeadlineExceeded(t *testing.T) {
+	banner := model.Creative{ID: "banner", Format: model.Banner, Review: model.Review{State: model.Approved}}
+	campaignRepo := &ports_out.CampaignRepositoryMock{
+		FindCandidatesFunc: func(ctx context.Context, tg model.Targeting, formats []model.CreativeFormat, sizes []model.Size) ([]model.Candidate, error) {
+			// the candidates are found once the caller stopped waiting
+			<-ctx.Done()
+			return []model.Candidate{{Campaign: model.Campaign{ID: "1", Active: true, Bid: decimal.NewFromFloat(5)},
+				Creatives: []model.Creative{banner}}}, nil
+		},
+	}
+	exposureRepo := &ports_out.ExposureRepositoryMock{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	service := NewService(campaignRepo, exposureRepo, Config{})
+	result, err := service.Match(ctx, model.MatchRequest{UserID: "user1", PageViewID: "view1"})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, model.MatchResult{}, result)
+	assert.Len(t, campaignRepo.DeliverCampaignsCalls(), 0)
+	assert.Len(t, exposureRepo.RecordExposuresCalls(), 0)
+	assert.Len(t, exposureRepo.RecordPageViewCalls(), 0)
+}
+
+// reverseStrategy orders the candidates from the lowest to the highest bid.
+type reverseStrategy struct{}
+
+func (reverseStrategy) Order(candidates []model.Candidate) []model.Candidate {
+	ordered := HighestBidStrategy{}.Order(candidates)
+	slices.Reverse(ordered)
+	return ordered
+}
@@ -0,0 +1,48 @@
+package campaign
+
+import (
+	"ad-campaign-delivery/model"
+	"github.com/shopspring/decimal"
+)
+
+// Predictor estimates how likely a delivery of the candidate is to be clicked and converted.
+type Predictor interface {
+	Predict(candidate model.Candidate) model.Prediction
+}
+
+// defaultPredictor predicts with the priors of the deployment defaults.
+var defaultPredictor = HistoricalPredictor{
+	PriorCTR: decimal.RequireFromString("0.01"),
+	PriorCVR: decimal.RequireFromString("0.001"),
+	Weight:   decimal.NewFromInt(1000),
+}
+
+// HistoricalPredictor predicts the rates from the counters of the campaign, smoothed towards
+// the rates of its targeting, which are in turn smoothed towards the priors. Weight is the
+// number of deliveries the smoothing is worth, so new campaigns start at the targeting rates.
+type HistoricalPredictor struct {
+	PriorCTR decimal.Decimal
+	PriorCVR decimal.Decimal
+	Weight   decimal.Decimal
+}
+
+// Predict implements Predictor.
+func (p HistoricalPredictor) Predict(candidate model.Candidate) model.Prediction {
+	targeting := candidate.TargetingStats
+	targetingCTR := p.smoothedRate(targeting.Clicks, targeting.Deliveries, p.PriorCTR)
+	targetingCVR := p.smoothedRate(targeting.Conversions, targeting.Deliveries, p.PriorCVR)
+
+	stats := candidate.Stats
+	return model.Prediction{
+		CTR: p.smoothedRate(stats.Clicks, stats.Deliveries, targetingCTR),
+		CVR: p.smoothedRate(stats.Conversions, stats.Deliveries, targetingCVR),
+	}
+}
+
+func (p HistoricalPredictor) smoothedRate(events, deliveries int64, prior decimal.Decimal) decimal.Decimal {
+	total := decimal.NewFromInt(deliveries).Add(p.Weight)
+	if !total.IsPositive() {
+		return prior
+	}
+	return decimal.NewFromInt(events).Add(prior.Mul(p.Weight)).Div(total)
+}
@@ -0,0 +1,58 @@
+package campaign
+
+import (
+	"testing"
+
+	"ad-campaign-delivery/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoricalPredictor_Predict(t *testing.T) {
+	predictor := HistoricalPredictor{
+		PriorCTR: decimal.NewFromFloat(0.01),
+		PriorCVR: decimal.NewFromFloat(0.001),
+		Weight:   decimal.NewFromInt(100),
+	}
+
+	tests := []struct {
+		name      string
+		candidate model.Candidate
+		wantCTR   decimal.Decimal
+		wantCVR   decimal.Decimal
+	}{
+		{
+			name:      "without history, predicts the priors",
+			candidate: model.Candidate{},
+			wantCTR:   decimal.NewFromFloat(0.01),
+			wantCVR:   decimal.NewFromFloat(0.001),
+		},
+		{
+			name: "new campaign starts at the targeting rates",
+			candidate: model.Candidate{
+				TargetingStats: model.DeliveryStats{Deliveries: 900, Clicks: 45, Conversions: 9},
+			},
+			// (45 + 0.01 * 100) / (900 + 100), (9 + 0.001 * 100) / (900 + 100)
+			wantCTR: decimal.NewFromFloat(0.046),
+			wantCVR: decimal.NewFromFloat(0.0091),
+		},
+		{
+			name: "campaign history outweighs the targeting rates",
+			candidate: model.Candidate{
+				Stats:          model.DeliveryStats{Deliveries: 300, Clicks: 30, Conversions: 3},
+				TargetingStats: model.DeliveryStats{Deliveries: 900, Clicks: 45, Conversions: 9},
+			},
+			// (30 + 0.046 * 100) / (300 + 100), (3 + 0.0091 * 100) / (300 + 100)
+			wantCTR: decimal.NewFromFloat(0.0865),
+			wantCVR: decimal.NewFromFloat(0.009775),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prediction := predictor.Predict(tt.candidate)
+			assert.True(t, tt.wantCTR.Equal(prediction.CTR), "ctr %s, want %s", prediction.CTR, tt.wantCTR)
+			assert.True(t, tt.wantCVR.Equal(prediction.CVR), "cvr %s, want %s", prediction.CVR, tt.wantCVR)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package campaign
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"ad-campaign-delivery/model"
+)
+
+// Strategy orders the candidates of a delivery, already scored with their effective CPM,
+// in the order they are offered the slots.
+type Strategy interface {
+	Order(candidates []model.Candidate) []model.Candidate
+}
+
+// Peeker is implemented by the strategies whose Order takes turns, to order the candidates
+// the same way without taking one, e.g. to explain a delivery without affecting the next ones.
+type Peeker interface {
+	Peek(candidates []model.Candidate) []model.Candidate
+}
+
+// peek orders the candidates with the strategy without taking a turn when it implements Peeker.
+func peek(strategy Strategy, candidates []model.Candidate) []model.Candidate {
+	if p, ok := strategy.(Peeker); ok {
+		return p.Peek(candidates)
+	}
+	return strategy.Order(candidates)
+}
+
+// NewStrategy returns the implementation of the ranking strategy, highest bid by default.
+func NewStrategy(strategy model.RankingStrategy) Strategy {
+	switch strategy {
+	case model.WeightedRandom:
+		return WeightedRandomStrategy{Rand: rand.Float64}
+	case model.RoundRobin:
+		return NewRoundRobinStrategy()
+	default:
+		return HighestBidStrategy{}
+	}
+}
+
+// HighestBidStrategy orders the candidates from the highest to the lowest
+// effective CPM, keeping older campaigns first on ties.
+type HighestBidStrategy struct{}
+
+// Order implements Strategy.
+func (HighestBidStrategy) Order(candidates []model.Candidate) []model.Candidate {
+	ordered := slices.Clone(candidates)
+	slices.SortStableFunc(ordered, func(a, b model.Candidate) int {
+		if c := b.ECPM.Cmp(a.ECPM); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.CreatedAt.UnixNano(), b.CreatedAt.UnixNano())
+	})
+	return ordered
+}
+
+// WeightedRandomStrategy draws the candidates one after the other, each with a probability
+// proportional to its effective CPM. Candidates without value come last, by highest bid.
+type WeightedRandomStrategy struct {
+	// Rand returns a pseudo-random number in [0.0, 1.0).
+	Rand func() float64
+}
+
+// Order implements Strategy.
+func (s WeightedRandomStrategy) Order(candidates []model.Candidate) []model.Candidate {
+	ordered := HighestBidStrategy{}.Order(candidates)
+
+	// a weighted draw without replacement is a sort by u^(1/weight), u uniform in [0, 1)
+	keys := make(map[string]float64, len(ordered))
+	for _, c := range ordered {
+		weight := c.ECPM.InexactFloat64()
+		if weight > 0 {
+			keys[c.ID] = math.Pow(s.Rand(), 1/weight)
+		}
+	}
+
+	slices.SortStableFunc(ordered, func(a, b model.Candidate) int {
+		return cmp.Compare(keys[b.ID], keys[a.ID])
+	})
+	return ordered
+}
+
+// RoundRobinStrategy orders the candidates by highest bid, but candidates tied on effective
+// CPM take turns at the front of their tie instead of the oldest one always winning: the
+// candidate that led a tie the longest ago, or never, goes first.
+type RoundRobinStrategy struct {
+	mu sync.Mutex
+	// turns holds the last turn each campaign led its tie, by campaign ID, so it grows with
+	// the campaigns rather than with the sets of tied campaigns.
+	turns map[string]int
+	turn  int
+}
+
+func NewRoundRobinStrategy() *RoundRobinStrategy {
+	return &RoundRobinStrategy{turns: map[string]int{}}
+}
+
+// Order implements Strategy.
+func (s *RoundRobinStrategy) Order(candidates []model.Candidate) []model.Candidate {
+	return s.order(candidates, true)
+}
+
+// Peek implements Peeker.
+func (s *RoundRobinStrategy) Peek(candidates []model.Candidate) []model.Candidate {
+	return s.order(candidates, false)
+}
+
+// order puts the candidate of each tie whose turn it is at its front, taking the turn when told to.
+func (s *RoundRobinStrategy) order(candidates []model.Candidate, takeTurn bool) []model.Candidate {
+	ordered := HighestBidStrategy{}.Order(candidates)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for start := 0; start < len(ordered); {
+		end := start + 1
+		for end < len(ordered) && ordered[end].ECPM.Equal(ordered[start].ECPM) {
+			end++
+		}
+
+		if tie := ordered[start:end]; len(tie) > 1 {
+			slices.SortStableFunc(tie, func(a, b model.Candidate) int {
+				return cmp.Compare(s.turns[a.ID], s.turns[b.ID])
+			})
+			if takeTurn {
+				s.turn++
+				s.turns[tie[0].ID] = s.turn
+			}
+		}
+		start = end
+	}
+	return ordered
+}
@@ -0,0 +1,99 @@
+package campaign
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"ad-campaign-delivery/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func scoredCandidate(id string, ecpm float64, createdAt time.Time) model.Candidate {
+	return model.Candidate{
+		Campaign: model.Campaign{ID: id, CreatedAt: createdAt},
+		ECPM:     decimal.NewFromFloat(ecpm),
+	}
+}
+
+func candidateIDs(candidates []model.Candidate) []string {
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func TestHighestBidStrategy_Order(t *testing.T) {
+	now := time.Now()
+	candidates := []model.Candidate{
+		scoredCandidate("low", 1, now),
+		scoredCandidate("new", 5, now),
+		scoredCandidate("old", 5, now.Add(-time.Hour)),
+		scoredCandidate("high", 8, now),
+	}
+
+	ordered := HighestBidStrategy{}.Order(candidates)
+
+	assert.Equal(t, []string{"high", "old", "new", "low"}, candidateIDs(ordered))
+	assert.Equal(t, "low", candidates[0].ID, "the candidates of the caller are not reordered")
+}
+
+func TestWeightedRandomStrategy_Order(t *testing.T) {
+	now := time.Now()
+	candidates := []model.Candidate{
+		scoredCandidate("zero", 0, now),
+		scoredCandidate("low", 1, now),
+		scoredCandidate("high", 3, now),
+	}
+	strategy := WeightedRandomStrategy{Rand: rand.New(rand.NewPCG(1, 2)).Float64}
+
+	draws := 10000
+	firsts := map[string]int{}
+	for range draws {
+		ordered := strategy.Order(candidates)
+		assert.Equal(t, "zero", ordered[2].ID, "candidates without value are never drawn first")
+		firsts[ordered[0].ID]++
+	}
+
+	assert.InDelta(t, 0.75, float64(firsts["high"])/float64(draws), 0.02)
+	assert.InDelta(t, 0.25, float64(firsts["low"])/float64(draws), 0.02)
+}
+
+func TestRoundRobinStrategy_Order(t *testing.T) {
+	now := time.Now()
+	candidates := []model.Candidate{
+		scoredCandidate("low", 1, now),
+		scoredCandidate("b", 5, now.Add(-time.Minute)),
+		scoredCandidate("a", 5, now.Add(-time.Hour)),
+		scoredCandidate("c", 5, now),
+	}
+	strategy := NewRoundRobinStrategy()
+
+	assert.Equal(t, []string{"a", "b", "c", "low"}, candidateIDs(strategy.Order(candidates)))
+	assert.Equal(t, []string{"b", "c", "a", "low"}, candidateIDs(strategy.Order(candidates)))
+	assert.Equal(t, []string{"c", "a", "b", "low"}, candidateIDs(strategy.Order(candidates)))
+	assert.Equal(t, []string{"a", "b", "c", "low"}, candidateIDs(strategy.Order(candidates)))
+
+	// the turns are kept per campaign, whatever the campaigns they tie with
+	assert.Equal(t, []string{"d", "b", "c"}, candidateIDs(strategy.Order([]model.Candidate{
+		candidates[1], candidates[3], scoredCandidate("d", 5, now),
+	})))
+	assert.Equal(t, []string{"b", "c", "a"}, candidateIDs(strategy.Order(candidates[1:])))
+	assert.Len(t, strategy.turns, 4)
+}
+
+func TestRoundRobinStrategy_Peek(t *testing.T) {
+	now := time.Now()
+	candidates := []model.Candidate{
+		scoredCandidate("b", 5, now),
+		scoredCandidate("a", 5, now.Add(-time.Hour)),
+	}
+	strategy := NewRoundRobinStrategy()
+
+	assert.Equal(t, []string{"a", "b"}, candidateIDs(strategy.Peek(candidates)))
+	assert.Equal(t, []string{"a", "b"}, candidateIDs(strategy.Order(candidates)))
+	assert.Equal(t, []string{"b", "a"}, candidateIDs(strategy.Peek(candidates)))
+	assert.Equal(t, []string{"b", "a"}, candidateIDs(strategy.Order(candidates)))
+}
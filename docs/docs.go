@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/campaigns": {
             "post": {
-                "description": "A campaign and a bid lookup will be created with the provided fields.",
+                "description": "A campaign and a bid lookup will be created with the provided fields.\nThe pricing model is one of cpd (bid per delivery, default), cpm, cpc or cpa.\nEmpty country, device or os match any value, and bid modifiers multiply the bid\nper country, device, os and hour of the day (0 to 23) of the delivery.\nThe frequency cap limits the deliveries to the same user within a period, e.g. 3 per 24h,\nand the recency cap sets the minimum time between two deliveries to the same user.\nA sequence delivers the campaign only to users who were delivered the campaign it follows.\nThe frequency cap period, recency cap and sequence min_delay cannot be longer than the exposure ttl.\nCampaigns of the same category from different advertisers are never delivered together.\nThe priority is one of guaranteed (wins before the auction at its bid), standard (default)\nor house (free, without budget, only filling the slots no other campaign won).\nAn impression goal sells a number of deliveries, spread evenly until the campaign expires,\ninstead of a budget: it requires active_days and no budget, and is best paired with guaranteed.\nFormats and sizes restrict the delivery to the slots of one of the formats and, for banners,\nof one of the sizes, only the creatives the campaign allows being delivered.",
                 "consumes": [
                     "application/json"
                 ],
@@ -55,9 +55,291 @@ const docTemplate = `{
                 }
             }
         },
-        "/campaigns/match": {
+        "/campaigns/{id}/creatives": {
+            "get": {
+                "description": "Returns the creatives of the campaign in creation order, the order they are selected in.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "creatives"
+                ],
+                "summary": "List the creatives of a campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Creatives of the campaign",
+                        "schema": {
+                            "$ref": "#/definitions/web.CreativesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a banner image, or an HTML snippet, of the given size to the campaign, a video whose\nmedia files are served by /deliver/vast, or native assets. Deliveries requesting slot sizes are made with the first\nbanner of the campaign fitting one of them, in creation order, and only to campaigns having one.\nClicks are redirected to the click_url of the creative, or of the campaign when it has none.\nThe creative is pending, and not delivered, until approved by its review.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "creatives"
+                ],
+                "summary": "Add a creative to a campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Creative request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreativeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Creative added (no content)"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/campaigns/{id}/creatives/{creative_id}": {
+            "delete": {
+                "description": "Removes the creative, which is no longer delivered. The clicks of the deliveries already made\nwith it are redirected to the click_url of the campaign.",
+                "tags": [
+                    "creatives"
+                ],
+                "summary": "Remove a creative from a campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Creative ID",
+                        "name": "creative_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Creative removed (no content)"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/campaigns/{id}/creatives/{creative_id}/approve": {
+            "post": {
+                "description": "Approves the creative for delivery after its review, including a creative rejected before.\nCampaigns are only delivered once one of their creatives is approved.",
+                "tags": [
+                    "creatives"
+                ],
+                "summary": "Approve a creative",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Creative ID",
+                        "name": "creative_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Creative approved (no content)"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/campaigns/{id}/creatives/{creative_id}/reject": {
+            "post": {
+                "description": "Rejects the creative after its review for the given reason, told to the advertiser in the\ncreatives of the campaign. Rejected creatives are no longer delivered, until approved again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "creatives"
+                ],
+                "summary": "Reject a creative",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Creative ID",
+                        "name": "creative_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreativeRejectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Creative rejected (no content)"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals": {
+            "put": {
+                "description": "Sets the deal with the given ID, replacing the existing deal. Deliveries requested with\nthe deal ID are reserved to its campaigns, which pay its fixed price per delivery.\nOmitted publishers allow any publisher to sell through the deal.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "Set a private marketplace deal",
+                "parameters": [
+                    {
+                        "description": "Deal request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.DealRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deal set (no content)"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/deliver": {
             "post": {
-                "description": "Matches a campaign based on country, device, and OS, after validating consent.",
+                "description": "Matches a campaign based on country, device, and OS, after validating consent.\nThe cost of the delivery at the clearing price, not the bid, is reserved from the campaign\nbudget: the clearing price for cpd, a thousandth of it for cpm and nothing for cpc and cpa.\nCalling the impression_url of the delivery confirms the charge when the ad is rendered,\notherwise the cost is released back to the budget once the reservation expires.\nThe click_url and conversion_url of the delivery record its clicks and conversions.\nCampaigns compete with their effective bid, their bid adjusted by their bid modifiers.\nCampaigns bidding below the request bid floor or the floor rules are skipped.\nWhen slots is informed, up to that many distinct campaigns are delivered in bid order,\nanswered as CampaignsMatchResponse, and each winner pays the bid of the next one.\nWhen user_id is informed, campaigns that reached their frequency or recency cap for the user\nare skipped. Sequenced campaigns are only delivered to users who saw the campaign they follow.\nCampaigns competing with the category of another winner, or of a campaign delivered earlier\nin the page view of page_view_id, are skipped.\nGuaranteed campaigns win before the auction at their bid, and house campaigns fill for free\nthe slots no other campaign won. Impression goal campaigns ahead of schedule are skipped\nmore and more often, so their deliveries are spread until they expire.\nWhen deal_ids is informed, the campaigns of the deals allowing publisher_id compete first and\npay the deal price, answered with their deal_id. The open auction is held when none of them\ncan be delivered, unless private_auction is set.\nWhen formats or sizes is informed, only the campaigns with an approved creative of one of the formats,\nbanners fitting one of the sizes, are delivered with the first such creative. Otherwise only the campaigns\nwith an approved banner are, with the first one. Native creatives are answered with their Native 1.2 response.",
                 "consumes": [
                     "application/json"
                 ],
@@ -88,13 +370,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Matched campaign",
+                        "description": "Matched campaigns, when slots is informed",
                         "schema": {
-                            "$ref": "#/definitions/web.CampaignMatchResponse"
+                            "$ref": "#/definitions/web.CampaignsMatchResponse"
                         }
                     },
                     "204": {
-                        "description": "No matching campaign found"
+                        "description": "No matching campaign found",
+                        "headers": {
+                            "X-No-Match-Reason": {
+                                "type": "string",
+                                "description": "no_active_campaign, below_floor, frequency_capped, recency_capped, out_of_sequence, competing_category, paced, no_creative or no_deal"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -110,70 +398,1667 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "pkg.ErrorResp": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string"
-                },
-                "errors": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                }
-            }
-        },
-        "web.CampaignCreateRequest": {
-            "type": "object",
-            "properties": {
-                "active_days": {
-                    "type": "integer"
-                },
-                "bid": {
-                    "type": "number"
-                },
-                "budget": {
-                    "type": "number"
-                },
-                "country": {
-                    "type": "string"
-                },
-                "device": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "os": {
-                    "type": "string"
-                }
-            }
         },
-        "web.CampaignMatchRequest": {
-            "type": "object",
-            "properties": {
-                "country": {
-                    "type": "string"
-                },
-                "device": {
+        "/deliver/explain": {
+            "post": {
+                "description": "Runs the delivery of the same request as /deliver without deducting any budget nor recording\nthe deliveries of the user and the page view, and returns every candidate in ranking order with\nits verdict: won, outranked, inactive, expired, out_of_budget, goal_reached, below_floor,\nfrequency_capped, recency_capped, out_of_sequence, paced, competing_category, no_creative,\nduplicate_advertiser or not_in_deal.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "campaigns"
+                ],
+                "summary": "Explain a delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Consent string",
+                        "name": "X-Consent-String",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Campaign match request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CampaignMatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.ExplainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/deliver/vast": {
+            "post": {
+                "description": "Delivers the video creative of the campaign matching the request like /deliver, as a VAST 4.2\ndocument for the video player. Only campaigns with a video creative are delivered, the formats\nand sizes of the request being ignored as the player picks the media file suiting it.\nThe impression, click-through and playback events of the document point at the tracking\nendpoints, besides the third-party tracking URLs of the creative, and the error URL releases\nthe delivery. When slots is set, the videos delivered form an ad pod played in sequence.\nAn empty VAST document is answered when no campaign matches, with the reason in the\nX-No-Match-Reason header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "deliveries"
+                ],
+                "summary": "Deliver a video ad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TCF v2 consent string",
+                        "name": "X-Consent-String",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivery request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CampaignMatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VAST document",
+                        "schema": {
+                            "$ref": "#/definitions/web.VAST"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/floor-rules": {
+            "put": {
+                "description": "Sets the minimum bid accepted for a country, device and OS, replacing the existing rule.\nOmitted targeting fields match any value; the most specific rule applies at delivery.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "floor-rules"
+                ],
+                "summary": "Set a bid floor rule",
+                "parameters": [
+                    {
+                        "description": "Floor rule request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.FloorRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Floor rule set (no content)"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/openrtb2/bid": {
+            "post": {
+                "description": "Matches a campaign for each impression of an OpenRTB 2.6 bid request, like /deliver with a single slot.\nThe country of device.geo is an ISO-3 code, and device.os and device.devicetype are mapped onto the\ntargeting: unknown values only match the campaigns targeting any value. The bid floors of the\nimpressions and the prices of the bids are per thousand deliveries.\nWhen regs.gdpr is 1, the consent string of user.consent, or user.ext.consent, must grant the consent\nrequired by /deliver, otherwise no bid is made. The user buyeruid, or id, caps the deliveries to\nthe user, and the bid request id is the page view of the impressions.\nEach bid reserves the cost of its delivery: its nurl confirms it at the clearing price of the\nexchange, and its lurl releases it. The impressions left once tmax elapsed are not bid on, and\nthe impression being matched then reserves nothing.\nThe sizes of the banner formats, or its w and h, are the sizes of the slot: the bids are made\nwith the adm, crid, w and h of the creative fitting it. The impressions with a native slot are bid\nwith the native creatives as well, whose adm is their Native 1.2 response to the native request.\nThe impressions without banner nor native slot, like the video ones, are not bid on.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "openrtb"
+                ],
+                "summary": "Bid on an OpenRTB request",
+                "parameters": [
+                    {
+                        "description": "OpenRTB 2.6 bid request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.OpenRTBBidRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bids on the impressions",
+                        "schema": {
+                            "$ref": "#/definitions/web.OpenRTBBidResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "No bid"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/prebid/bid": {
+            "post": {
+                "description": "Bids on the OpenRTB 2.6 requests of the Prebid Server bidder adapter like /openrtb2/bid, answering\nthe Prebid media type of each bid in its ext.prebid.type. The publisher_id of the bidder params in\nimp.ext.bidder identifies the publisher of the deals. The consent string passed through by Prebid\nServer in user.ext.consent, or user.consent, is checked when regs.ext.gdpr, or regs.gdpr, is 1,\nand the impressions left once tmax elapsed are not bid on.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "openrtb"
+                ],
+                "summary": "Bid as a Prebid Server bidder",
+                "parameters": [
+                    {
+                        "description": "OpenRTB 2.6 bid request of Prebid Server",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.OpenRTBBidRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bids on the impressions",
+                        "schema": {
+                            "$ref": "#/definitions/web.OpenRTBBidResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "No bid"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/t/click": {
+            "get": {
+                "description": "Records the click of the delivery, charged to cpc campaigns, and redirects to the\nclick_url of the creative delivered, or of the campaign when the creative has none.\nCalled through the click_url of the delivery, where repeated clicks of the same delivery\nare redirected without being recorded again.",
+                "tags": [
+                    "tracking"
+                ],
+                "summary": "Track a click",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed tracking token of the delivery",
+                        "name": "t",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Click recorded, neither the creative nor the campaign has a click_url (no content)"
+                    },
+                    "302": {
+                        "description": "Redirect to the click_url of the creative or the campaign"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/t/conv": {
+            "get": {
+                "description": "Records the conversion of the delivery, charged to cpa campaigns. Called through the\nconversion_url of the delivery, where repeated conversions of the same delivery are ignored.",
+                "produces": [
+                    "image/gif"
+                ],
+                "tags": [
+                    "tracking"
+                ],
+                "summary": "Track a conversion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed tracking token of the delivery",
+                        "name": "t",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transparent pixel"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/t/imp": {
+            "get": {
+                "description": "Confirms the delivery reserved by /deliver when its ad is rendered, charging it for good.\nCalled through the impression_url of the delivery before the reservation expires: the cost\nof the deliveries left unconfirmed is released back to the campaign budget.\nRepeated pings of the same delivery are ignored.",
+                "produces": [
+                    "image/gif"
+                ],
+                "tags": [
+                    "tracking"
+                ],
+                "summary": "Track an impression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed tracking token of the delivery",
+                        "name": "t",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transparent pixel"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/t/loss": {
+            "get": {
+                "description": "Releases the delivery reserved by a bid of /openrtb2/bid or /prebid/bid on the loss notice of the\nexchange, refunding its cost to the campaign budget. Called through the lurl of the bid.\nLoss notices of confirmed deliveries are ignored.",
+                "tags": [
+                    "tracking"
+                ],
+                "summary": "Notify a loss",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed tracking token of the delivery",
+                        "name": "t",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Delivery released (no content)"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/t/video": {
+            "get": {
+                "description": "Records the playback event of the video delivered by /deliver/vast, free of charge.\nCalled through the tracking events of the VAST document, where repeated events of the same\ndelivery are ignored.",
+                "produces": [
+                    "image/gif"
+                ],
+                "tags": [
+                    "tracking"
+                ],
+                "summary": "Track a video event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed tracking token of the delivery",
+                        "name": "t",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "start",
+                            "firstQuartile",
+                            "midpoint",
+                            "thirdQuartile",
+                            "complete"
+                        ],
+                        "type": "string",
+                        "description": "Playback event",
+                        "name": "event",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transparent pixel"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/t/win": {
+            "get": {
+                "description": "Confirms the delivery reserved by a bid of /openrtb2/bid or /prebid/bid on the win notice of the\nexchange, charging the clearing price of its auction instead of the reserved cost, and never more.\nCalled through the nurl of the bid, with the ${AUCTION_PRICE} macro substituted by the clearing\nprice per thousand deliveries.\nWin notices of confirmed deliveries are ignored, and the impression_url is not needed after a win.",
+                "tags": [
+                    "tracking"
+                ],
+                "summary": "Notify a win",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed tracking token of the delivery",
+                        "name": "t",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clearing price per thousand deliveries of the upstream auction",
+                        "name": "price",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Delivery confirmed (no content)"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorResp"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "pkg.ErrorResp": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "web.BidModifiersRequest": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "device": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "hour": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "os": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "web.CampaignCreateRequest": {
+            "type": "object",
+            "properties": {
+                "active_days": {
+                    "type": "integer"
+                },
+                "advertiser": {
+                    "type": "string"
+                },
+                "bid": {
+                    "type": "number"
+                },
+                "bid_modifiers": {
+                    "$ref": "#/definitions/web.BidModifiersRequest"
+                },
+                "budget": {
+                    "type": "number"
+                },
+                "category": {
+                    "description": "Category is the industry of the campaign, e.g. automotive.",
+                    "type": "string"
+                },
+                "click_url": {
+                    "description": "ClickURL is the landing page the clicks are redirected to.",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "Country, Device and OS match any value when empty.",
+                    "type": "string"
+                },
+                "device": {
+                    "type": "string"
+                },
+                "formats": {
+                    "description": "Formats lists the formats of the slots the campaign is delivered in, banner, native or video,\nand Sizes the sizes of its banner slots, e.g. 728x90. Both match any slot when empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "frequency_cap": {
+                    "$ref": "#/definitions/web.FrequencyCapRequest"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "impression_goal": {
+                    "description": "ImpressionGoal is the number of deliveries sold until the campaign expires, instead of a budget.",
+                    "type": "integer"
+                },
+                "os": {
+                    "type": "string"
+                },
+                "pricing_model": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "recency_cap": {
+                    "description": "RecencyCap is the minimum duration between two deliveries to the same user, e.g. 10m.",
+                    "type": "string"
+                },
+                "sequence": {
+                    "$ref": "#/definitions/web.SequenceRequest"
+                },
+                "sizes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "web.CampaignMatchRequest": {
+            "type": "object",
+            "properties": {
+                "bid_floor": {
+                    "type": "number"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "deal_ids": {
+                    "description": "DealIDs reserves the delivery to the campaigns of these deals, at the deal price.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "device": {
+                    "type": "string"
+                },
+                "formats": {
+                    "description": "Formats lists the creative formats the slots accept, banner, native or video, only the campaigns\nallowing one of them with a creative of one of them being delivered.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "os": {
+                    "type": "string"
+                },
+                "page_view_id": {
+                    "description": "PageViewID identifies the page view of the slots, to exclude the competitors of the\ncategories delivered by previous requests of the same page view.",
+                    "type": "string"
+                },
+                "private_auction": {
+                    "description": "PrivateAuction answers no match instead of falling back to the open auction\nwhen no campaign of the deals can be delivered.",
+                    "type": "boolean"
+                },
+                "publisher_id": {
+                    "description": "PublisherID identifies the publisher of the inventory, checked against the publishers of the deals.",
+                    "type": "string"
+                },
+                "sizes": {
+                    "description": "Sizes lists the sizes the banner slots accept, e.g. 300x250, only the campaigns allowing one of\nthem with a banner fitting one of them being delivered.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "slots": {
+                    "description": "Slots requests up to this many distinct campaigns, answered with CampaignsMatchResponse.",
+                    "type": "integer"
+                },
+                "unique_advertisers": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "description": "UserID identifies the user for frequency and recency capping and sequencing.",
+                    "type": "string"
+                }
+            }
+        },
+        "web.CampaignMatchResponse": {
+            "type": "object",
+            "properties": {
+                "bid": {
+                    "type": "number"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
+                "clearing_cpm": {
+                    "description": "ClearingCPM is the clearing price per thousand deliveries.",
+                    "type": "number"
+                },
+                "clearing_price": {
+                    "type": "number"
+                },
+                "click_url": {
+                    "type": "string"
+                },
+                "conversion_url": {
+                    "type": "string"
+                },
+                "creative": {
+                    "description": "Creative is the approved creative to render. It links to the click_url of the delivery,\nwhich redirects to the click_url of the creative.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.CreativeResponse"
+                        }
+                    ]
+                },
+                "deal_id": {
+                    "type": "string"
+                },
+                "effective_bid": {
+                    "type": "number"
+                },
+                "impression_url": {
+                    "description": "ImpressionURL confirms the delivery when called as the ad is rendered, ClickURL records\nthe clicks and redirects to the campaign landing page, and ConversionURL records the conversions.",
+                    "type": "string"
+                },
+                "native": {
+                    "description": "Native is the Native 1.2 response of the native creatives, linking to the click_url and\ntracking the impression_url of the delivery.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.OpenRTBNativeResponse"
+                        }
+                    ]
+                },
+                "pricing_model": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.CampaignsMatchResponse": {
+            "type": "object",
+            "properties": {
+                "campaigns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.CampaignMatchResponse"
+                    }
+                }
+            }
+        },
+        "web.CandidateExplanationResponse": {
+            "type": "object",
+            "properties": {
+                "bid": {
+                    "type": "number"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
+                "clearing_price": {
+                    "description": "ClearingPrice and DealID are only set for the winners.",
+                    "type": "number"
+                },
+                "deal_id": {
+                    "type": "string"
+                },
+                "ecpm": {
+                    "type": "number"
+                },
+                "effective_bid": {
+                    "type": "number"
+                },
+                "pricing_model": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "verdict": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.CreativeRejectRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.CreativeRequest": {
+            "type": "object",
+            "properties": {
+                "click_url": {
+                    "description": "ClickURL is the click-through URL of the creative, the click_url of the campaign when empty.",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "Format is banner, the default, video or native.",
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "description": "ImageURL and HTML are exclusive: the banner is either an image or an HTML snippet.",
+                    "type": "string"
+                },
+                "native": {
+                    "$ref": "#/definitions/web.NativeRequest"
+                },
+                "video": {
+                    "$ref": "#/definitions/web.VideoRequest"
+                },
+                "width": {
+                    "description": "Width and Height are the size of the banners, videos being sized by their media files.",
+                    "type": "integer"
+                }
+            }
+        },
+        "web.CreativeResponse": {
+            "type": "object",
+            "properties": {
+                "click_url": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "native": {
+                    "$ref": "#/definitions/web.NativeRequest"
+                },
+                "review": {
+                    "description": "Review is only listed in the creatives of the campaign, the deliveries being of approved creatives.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.ReviewResponse"
+                        }
+                    ]
+                },
+                "video": {
+                    "$ref": "#/definitions/web.VideoRequest"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.CreativesResponse": {
+            "type": "object",
+            "properties": {
+                "creatives": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.CreativeResponse"
+                    }
+                }
+            }
+        },
+        "web.DealRequest": {
+            "type": "object",
+            "properties": {
+                "campaigns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price is the fixed price per delivery the campaigns of the deal pay.",
+                    "type": "number"
+                },
+                "publishers": {
+                    "description": "Publishers allowed to sell their inventory through the deal, any publisher when empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "web.ExplainResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.CandidateExplanationResponse"
+                    }
+                },
+                "floor": {
+                    "type": "number"
+                },
+                "no_match_reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.FloorRuleRequest": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "device": {
+                    "type": "string"
+                },
+                "floor": {
+                    "type": "number"
+                },
+                "os": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.FrequencyCapRequest": {
+            "type": "object",
+            "properties": {
+                "impressions": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.MediaFileRequest": {
+            "type": "object",
+            "properties": {
+                "bitrate": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.NativeImageRequest": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.NativeRequest": {
+            "type": "object",
+            "properties": {
+                "cta": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "icon": {
+                    "$ref": "#/definitions/web.NativeImageRequest"
+                },
+                "image": {
+                    "$ref": "#/definitions/web.NativeImageRequest"
+                },
+                "sponsored_by": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBBanner": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.OpenRTBFormat"
+                    }
+                },
+                "h": {
+                    "type": "integer"
+                },
+                "w": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.OpenRTBBid": {
+            "type": "object",
+            "properties": {
+                "adm": {
+                    "type": "string"
+                },
+                "cid": {
+                    "type": "string"
+                },
+                "crid": {
+                    "type": "string"
+                },
+                "dealid": {
+                    "type": "string"
+                },
+                "ext": {
+                    "$ref": "#/definitions/web.OpenRTBBidExt"
+                },
+                "h": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "impid": {
+                    "type": "string"
+                },
+                "lurl": {
+                    "type": "string"
+                },
+                "mtype": {
+                    "type": "integer"
+                },
+                "nurl": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "w": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.OpenRTBBidExt": {
+            "type": "object",
+            "properties": {
+                "prebid": {
+                    "$ref": "#/definitions/web.PrebidBidExt"
+                }
+            }
+        },
+        "web.OpenRTBBidRequest": {
+            "type": "object",
+            "properties": {
+                "app": {
+                    "$ref": "#/definitions/web.OpenRTBInventory"
+                },
+                "device": {
+                    "$ref": "#/definitions/web.OpenRTBDevice"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imp": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.OpenRTBImp"
+                    }
+                },
+                "regs": {
+                    "$ref": "#/definitions/web.OpenRTBRegs"
+                },
+                "site": {
+                    "$ref": "#/definitions/web.OpenRTBInventory"
+                },
+                "tmax": {
+                    "description": "TMax is the time in milliseconds the exchange waits for the bids, unlimited when unset.",
+                    "type": "integer"
+                },
+                "user": {
+                    "$ref": "#/definitions/web.OpenRTBUser"
+                }
+            }
+        },
+        "web.OpenRTBBidResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "seatbid": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.OpenRTBSeatBid"
+                    }
+                }
+            }
+        },
+        "web.OpenRTBBidderParams": {
+            "type": "object",
+            "properties": {
+                "publisher_id": {
+                    "description": "PublisherID identifies the publisher of the deals, instead of the site or app publisher.",
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBDeal": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBDevice": {
+            "type": "object",
+            "properties": {
+                "devicetype": {
+                    "type": "integer"
+                },
+                "geo": {
+                    "$ref": "#/definitions/web.OpenRTBGeo"
+                },
+                "os": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBFormat": {
+            "type": "object",
+            "properties": {
+                "h": {
+                    "type": "integer"
+                },
+                "w": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.OpenRTBGeo": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBImp": {
+            "type": "object",
+            "properties": {
+                "banner": {
+                    "$ref": "#/definitions/web.OpenRTBBanner"
+                },
+                "bidfloor": {
+                    "type": "number"
+                },
+                "ext": {
+                    "$ref": "#/definitions/web.OpenRTBImpExt"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "native": {
+                    "$ref": "#/definitions/web.OpenRTBNative"
+                },
+                "pmp": {
+                    "$ref": "#/definitions/web.OpenRTBPMP"
+                }
+            }
+        },
+        "web.OpenRTBImpExt": {
+            "type": "object",
+            "properties": {
+                "bidder": {
+                    "$ref": "#/definitions/web.OpenRTBBidderParams"
+                }
+            }
+        },
+        "web.OpenRTBInventory": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "publisher": {
+                    "$ref": "#/definitions/web.OpenRTBPublisher"
+                }
+            }
+        },
+        "web.OpenRTBNative": {
+            "type": "object",
+            "properties": {
+                "request": {
                     "type": "string"
                 },
-                "os": {
+                "ver": {
                     "type": "string"
                 }
             }
         },
-        "web.CampaignMatchResponse": {
+        "web.OpenRTBNativeAsset": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/web.OpenRTBNativeData"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "img": {
+                    "$ref": "#/definitions/web.OpenRTBNativeImage"
+                },
+                "title": {
+                    "$ref": "#/definitions/web.OpenRTBNativeTitle"
+                }
+            }
+        },
+        "web.OpenRTBNativeData": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBNativeEventTracker": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBNativeImage": {
+            "type": "object",
+            "properties": {
+                "h": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "w": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.OpenRTBNativeLink": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBNativeResponse": {
+            "type": "object",
+            "properties": {
+                "assets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.OpenRTBNativeAsset"
+                    }
+                },
+                "eventtrackers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.OpenRTBNativeEventTracker"
+                    }
+                },
+                "link": {
+                    "$ref": "#/definitions/web.OpenRTBNativeLink"
+                },
+                "ver": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBNativeTitle": {
+            "type": "object",
+            "properties": {
+                "len": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBPMP": {
+            "type": "object",
+            "properties": {
+                "deals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.OpenRTBDeal"
+                    }
+                },
+                "private_auction": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.OpenRTBPublisher": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBRegs": {
+            "type": "object",
+            "properties": {
+                "ext": {
+                    "$ref": "#/definitions/web.OpenRTBRegsExt"
+                },
+                "gdpr": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.OpenRTBRegsExt": {
+            "type": "object",
+            "properties": {
+                "gdpr": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.OpenRTBSeatBid": {
             "type": "object",
             "properties": {
                 "bid": {
-                    "type": "number"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.OpenRTBBid"
+                    }
+                }
+            }
+        },
+        "web.OpenRTBUser": {
+            "type": "object",
+            "properties": {
+                "buyeruid": {
+                    "type": "string"
                 },
-                "campaign_id": {
+                "consent": {
+                    "type": "string"
+                },
+                "ext": {
+                    "$ref": "#/definitions/web.OpenRTBUserExt"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.OpenRTBUserExt": {
+            "type": "object",
+            "properties": {
+                "consent": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.PrebidBidExt": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.SequenceRequest": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string"
+                },
+                "min_delay": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.VAST": {
+            "type": "object",
+            "properties": {
+                "ads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.VASTAd"
+                    }
+                },
+                "version": {
+                    "type": "string"
+                },
+                "xmlname": {
+                    "$ref": "#/definitions/xml.Name"
+                },
+                "xmlns": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.VASTAd": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "inLine": {
+                    "$ref": "#/definitions/web.VASTInLine"
+                },
+                "sequence": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.VASTCreative": {
+            "type": "object",
+            "properties": {
+                "adID": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "linear": {
+                    "$ref": "#/definitions/web.VASTLinear"
+                },
+                "universalAdID": {
+                    "$ref": "#/definitions/web.VASTUniversalAdID"
+                }
+            }
+        },
+        "web.VASTInLine": {
+            "type": "object",
+            "properties": {
+                "adServingID": {
+                    "type": "string"
+                },
+                "adSystem": {
+                    "type": "string"
+                },
+                "adTitle": {
+                    "type": "string"
+                },
+                "creatives": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.VASTCreative"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/web.VASTURL"
+                },
+                "impression": {
+                    "$ref": "#/definitions/web.VASTURL"
+                }
+            }
+        },
+        "web.VASTLinear": {
+            "type": "object",
+            "properties": {
+                "clickThrough": {
+                    "$ref": "#/definitions/web.VASTURL"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "mediaFiles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.VASTMediaFile"
+                    }
+                },
+                "trackingEvents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.VASTTracking"
+                    }
+                }
+            }
+        },
+        "web.VASTMediaFile": {
+            "type": "object",
+            "properties": {
+                "bitrate": {
+                    "type": "integer"
+                },
+                "delivery": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.VASTTracking": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.VASTURL": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.VASTUniversalAdID": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "idregistry": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.VideoRequest": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "media_files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.MediaFileRequest"
+                    }
+                },
+                "tracking_events": {
+                    "description": "TrackingEvents lists the third-party URLs called on each event of the playback, by VAST event name,\ne.g. {\"complete\": [\"https://tracker.example.com/complete\"]}.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "xml.Name": {
+            "type": "object",
+            "properties": {
+                "local": {
+                    "type": "string"
+                },
+                "space": {
                     "type": "string"
                 }
             }
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+type (
+	AuctionType string
+)
+
+// REMINDER: also insert the auction type in map AuctionTypes whenever
+// a new auction type is added as a constant.
+const (
+	FirstPrice  AuctionType = "first_price"
+	SecondPrice AuctionType = "second_price"
+)
+
+var AuctionTypes = map[string]AuctionType{
+	"first_price":  FirstPrice,
+	"second_price": SecondPrice,
+}
+
+// Auction holds the rules used to clear the price paid by the winning campaign.
+type Auction struct {
+	Type AuctionType
+	// MinIncrement is added to the runner-up bid in second price auctions.
+	MinIncrement decimal.Decimal
+	// Floor is the price paid in second price auctions when there is no runner-up.
+	Floor decimal.Decimal
+}
+
+// ClearingPrice returns the price the winner pays for its bid. In first price auctions
+// the winner pays its own bid; in second price auctions it pays the runner-up bid plus
+// the minimum increment, or the auction floor when it competed alone. The price is never
+// lower than the bid floor of the request nor higher than the bid.
+func (a Auction) ClearingPrice(bid decimal.Decimal, runnerUp *decimal.Decimal, bidFloor decimal.Decimal) decimal.Decimal {
+	if a.Type != SecondPrice {
+		return bid
+	}
+
+	price := a.Floor
+	if runnerUp != nil {
+		price = runnerUp.Add(a.MinIncrement)
+	}
+	return decimal.Min(decimal.Max(price, bidFloor), bid)
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"slices"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -9,12 +10,31 @@ import (
 // Campaign represents the complete advertising campaign
 // with targeting and budget information.
 type Campaign struct {
-	ID        string
-	Country   Country
-	Device    Device
-	OS        OS
-	Bid       decimal.Decimal
-	Budget    decimal.Decimal
+	ID         string
+	Advertiser string
+	Category   Category
+	// Country, Device and OS are the targeting of the campaign, empty fields matching any value.
+	Country      Country
+	Device       Device
+	OS           OS
+	Bid          decimal.Decimal
+	BidModifiers BidModifiers
+	PricingModel PricingModel
+	Priority     Priority
+	Budget       decimal.Decimal
+	// ImpressionGoal is the number of deliveries sold between the creation and the expiration
+	// of the campaign instead of a budget, zero for budget campaigns.
+	ImpressionGoal int64
+	FrequencyCap   FrequencyCap
+	// RecencyCap is the minimum time between two deliveries to the same user, zero when unset.
+	RecencyCap time.Duration
+	Sequence   Sequence
+	// ClickURL is the landing page the clicks are redirected to.
+	ClickURL string
+	// Formats and Sizes restrict the slots the campaign is delivered in to the ones accepting
+	// one of its formats and, for banners, one of its sizes, any slot when empty.
+	Formats   []CreativeFormat
+	Sizes     []Size
 	Active    bool
 	CreatedAt time.Time
 	ExpiresAt time.Time
@@ -28,9 +48,50 @@ type Campaigns map[string]Campaign
 // allowing efficient lookup and retrieval of bid data for targeted campaign delivery.
 type CampaignsLookup map[Country]map[Device]map[OS][]BidLookup
 
+// SlotsLookup indexes the campaign IDs by the formats and banner sizes they allow, the campaigns
+// allowing any format or size being under the empty format or size.
+type SlotsLookup struct {
+	Formats map[CreativeFormat]map[string]bool
+	Sizes   map[Size]map[string]bool
+}
+
 // BidLookup contains the minimal campaign data required for
 // bid delivery, including campaign ID and bid amount.
 type BidLookup struct {
 	ID  string
 	Bid decimal.Decimal
 }
+
+// CampaignMatch is the campaign chosen to be delivered, with its bid, the effective bid
+// it competed with after modifiers and the clearing price deducted from its budget.
+type CampaignMatch struct {
+	ID            string
+	Bid           decimal.Decimal
+	EffectiveBid  decimal.Decimal
+	PricingModel  PricingModel
+	Priority      Priority
+	ClearingPrice decimal.Decimal
+	// ClearingCPM is the clearing price per thousand deliveries whatever the pricing model,
+	// the price bid for the delivery in upstream auctions.
+	ClearingCPM decimal.Decimal
+	// DealID is the deal the campaign was delivered through, empty in the open auction.
+	DealID string
+	// Creative is the approved creative of the campaign fitting the slot.
+	Creative Creative
+	// ReservationID identifies the delivery until its impression confirms it.
+	ReservationID string
+}
+
+// AllowsCreative tells whether the creative is of one of the formats of the campaign and,
+// for banners, of one of its sizes.
+func (c Campaign) AllowsCreative(creative Creative) bool {
+	if len(c.Formats) > 0 && !slices.Contains(c.Formats, creative.Format) {
+		return false
+	}
+	return creative.Format != Banner || len(c.Sizes) == 0 || slices.Contains(c.Sizes, creative.Size)
+}
+
+// Targeting returns the country, device and OS the campaign is delivered for.
+func (c Campaign) Targeting() Targeting {
+	return Targeting{Country: c.Country, Device: c.Device, OS: c.OS}
+}
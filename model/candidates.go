@@ -0,0 +1,43 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Candidate is a campaign competing for a delivery, with the stats used to rank it.
+type Candidate struct {
+	Campaign
+	// Stats are the counters of the campaign, and TargetingStats the counters
+	// of all campaigns sharing its country, device and OS.
+	Stats          DeliveryStats
+	TargetingStats DeliveryStats
+	// EffectiveBid is the bid adjusted by the bid modifiers of the delivery, and ECPM
+	// the effective value of a thousand deliveries at this bid, set by the ranking stage.
+	EffectiveBid decimal.Decimal
+	ECPM         decimal.Decimal
+	// Deal is the deal the candidate competes through, empty in the open auction.
+	Deal Deal
+	// Creatives are the creatives of the campaign, in creation order.
+	Creatives []Creative
+}
+
+// Prediction holds the estimated rates of clicks and conversions per delivery.
+type Prediction struct {
+	CTR decimal.Decimal
+	CVR decimal.Decimal
+}
+
+// EffectiveCPM returns the effective value of a thousand deliveries of the candidate,
+// converting its effective bid with the predicted rates of its pricing model.
+func (c Candidate) EffectiveCPM(p Prediction) decimal.Decimal {
+	switch c.PricingModel {
+	case CPM:
+		return c.EffectiveBid
+	case CPC:
+		return c.EffectiveBid.Mul(p.CTR).Shift(3)
+	case CPA:
+		return c.EffectiveBid.Mul(p.CVR).Shift(3)
+	default:
+		return c.EffectiveBid.Shift(3)
+	}
+}
@@ -0,0 +1,26 @@
+package model
+
+// Category is the industry of a campaign, e.g. automotive. Campaigns of the same
+// category from different advertisers compete and are not delivered together.
+type Category string
+
+// PageView holds the categories delivered during a page view,
+// each mapped to the advertiser delivered for it.
+type PageView map[Category]string
+
+// Competes tells whether the campaign competes with a campaign of another advertiser
+// delivered in the page view. Campaigns without advertiser compete with any other.
+func (p PageView) Competes(c Campaign) bool {
+	if c.Category == "" {
+		return false
+	}
+	advertiser, ok := p[c.Category]
+	return ok && (c.Advertiser == "" || advertiser != c.Advertiser)
+}
+
+// Add records the delivery of the campaign in the page view.
+func (p PageView) Add(c Campaign) {
+	if _, ok := p[c.Category]; c.Category != "" && !ok {
+		p[c.Category] = c.Advertiser
+	}
+}
@@ -4,8 +4,8 @@ type (
 	Country string
 )
 
-// REMINDER: also insert the country in map Countries whenever
-// a new country is added as a constant.
+// REMINDER: also insert the country in maps Countries and CountriesISO3
+// whenever a new country is added as a constant.
 const (
 	France       Country = "FR"
 	Spain        Country = "ES"
@@ -19,3 +19,11 @@ var Countries = map[string]Country{
 	"UK": UK,
 	"US": UnitedStates,
 }
+
+// CountriesISO3 maps the ISO 3166-1 alpha-3 codes of the countries, as used by OpenRTB.
+var CountriesISO3 = map[string]Country{
+	"FRA": France,
+	"ESP": Spain,
+	"GBR": UK,
+	"USA": UnitedStates,
+}
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+type CreativeFormat string
+
+// REMINDER: also insert the format in map CreativeFormats whenever
+// a new format is added as a constant.
+const (
+	// Banner creatives are an image or an HTML snippet of their size.
+	Banner CreativeFormat = "banner"
+	// Video creatives are a linear video, served in VAST documents.
+	Video CreativeFormat = "video"
+	// Native creatives are assets rendered by the publisher in the look of its content.
+	Native CreativeFormat = "native"
+)
+
+var CreativeFormats = map[string]CreativeFormat{
+	"banner": Banner,
+	"video":  Video,
+	"native": Native,
+}
+
+type ReviewState string
+
+// REMINDER: also insert the state in map ReviewStates whenever
+// a new state is added as a constant.
+const (
+	// Pending creatives were not reviewed yet.
+	Pending ReviewState = "pending"
+	// Approved creatives are delivered.
+	Approved ReviewState = "approved"
+	// Rejected creatives are never delivered, for the reason of their review.
+	Rejected ReviewState = "rejected"
+)
+
+var ReviewStates = map[string]ReviewState{
+	"pending":  Pending,
+	"approved": Approved,
+	"rejected": Rejected,
+}
+
+// Review is the review state of a creative, with the reason of its rejection and when it was reviewed,
+// zero while pending.
+type Review struct {
+	State      ReviewState
+	Reason     string
+	ReviewedAt time.Time
+}
+
+// Size is the width and height of a slot or a creative, in pixels.
+type Size struct {
+	Width  int
+	Height int
+}
+
+func (s Size) String() string {
+	return fmt.Sprintf("%dx%d", s.Width, s.Height)
+}
+
+// Creative is the ad rendered by the deliveries of its campaign: a banner image or an HTML snippet
+// of its size, a linear video or native assets. Clicks are redirected to its click URL, or to the click URL of the
+// campaign when empty. Only the creatives approved by their review are delivered.
+type Creative struct {
+	ID         string
+	CampaignID string
+	Format     CreativeFormat
+	Size       Size
+	ImageURL   string
+	HTML       string
+	Video      LinearVideo
+	Native     NativeAd
+	ClickURL   string
+	Review     Review
+}
+
+// Approved tells whether the review of the creative approved it for delivery.
+func (c Creative) Approved() bool {
+	return c.Review.State == Approved
+}
+
+// LinearVideo is the video of a video creative, played before, during or after the content.
+// TrackingEvents lists the third-party URLs called on each event of the playback, besides ours.
+type LinearVideo struct {
+	Duration       time.Duration
+	MediaFiles     []MediaFile
+	TrackingEvents map[EventType][]string
+}
+
+// MediaFile is an encoding of a video, the player picking the one that suits it best.
+// Bitrate is in kbit/s, zero when unknown.
+type MediaFile struct {
+	URL      string
+	MIMEType string
+	Width    int
+	Height   int
+	Bitrate  int
+}
+
+// NativeAd holds the assets of a native creative, rendered by the publisher: only the title, the main
+// image and the advertiser it is sponsored by are always set.
+type NativeAd struct {
+	Title       string
+	Description string
+	Icon        NativeImage
+	Image       NativeImage
+	// CTA is the call to action text of the click, e.g. Shop now.
+	CTA         string
+	SponsoredBy string
+}
+
+// NativeImage is an image asset of a native creative, empty when the creative has none.
+type NativeImage struct {
+	URL  string
+	Size Size
+}
+
+// Creatives is the creative table, where each campaign ID maps to its creatives in creation order.
+type Creatives map[string][]Creative
+
+// CreativeFor returns the first creative of the candidate in one of the formats, banner when none is
+// given, banners fitting one of the slot sizes when given, among the approved creatives its campaign
+// allows. It returns false when no creative fits.
+func (c Candidate) CreativeFor(formats []CreativeFormat, sizes []Size) (Creative, bool) {
+	if len(formats) == 0 {
+		formats = []CreativeFormat{Banner}
+	}
+	for _, creative := range c.Creatives {
+		fits := creative.Format != Banner || len(sizes) == 0 || slices.Contains(sizes, creative.Size)
+		if creative.Approved() && slices.Contains(formats, creative.Format) && fits && c.Campaign.AllowsCreative(creative) {
+			return creative, true
+		}
+	}
+	return Creative{}, false
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"slices"
+
+	"github.com/shopspring/decimal"
+)
+
+// Deal is a private marketplace agreement: the campaigns of the deal are delivered at its fixed
+// price per delivery on the inventory of its publishers, or of any publisher when it has none.
+type Deal struct {
+	ID         string
+	Price      decimal.Decimal
+	Campaigns  []string
+	Publishers []string
+}
+
+// Deals is the deal table, where each deal ID maps to its deal.
+type Deals map[string]Deal
+
+// Allows tells whether the inventory of the publisher can be sold through the deal.
+func (d Deal) Allows(publisher string) bool {
+	return len(d.Publishers) == 0 || slices.Contains(d.Publishers, publisher)
+}
+
+// Includes tells whether the campaign is attached to the deal.
+func (d Deal) Includes(campaignID string) bool {
+	return slices.Contains(d.Campaigns, campaignID)
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DeliveryStats holds the counters of deliveries and of the events that followed them.
+type DeliveryStats struct {
+	Deliveries  int64
+	Clicks      int64
+	Conversions int64
+	Video       VideoStats
+}
+
+// Delivery is the cost of a delivered campaign, deducted from its budget when the delivery is
+// reserved, and released back to it when the reservation expires before the impression confirms it.
+type Delivery struct {
+	ReservationID string
+	CampaignID    string
+	Cost          decimal.Decimal
+	ExpiresAt     time.Time
+	// UserID and PageViewID are those of the request, empty when it had none, whose history
+	// recorded the delivery at ReservedAt, forgotten again when the delivery is released.
+	UserID     string
+	PageViewID string
+	Category   Category
+	Advertiser string
+	ReservedAt time.Time
+}
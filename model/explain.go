@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Verdict tells whether a candidate would be delivered, or why not.
+type Verdict string
+
+const (
+	Won                 Verdict = "won"
+	Outranked           Verdict = "outranked"
+	Inactive            Verdict = "inactive"
+	Expired             Verdict = "expired"
+	OutOfBudget         Verdict = "out_of_budget"
+	GoalReached         Verdict = "goal_reached"
+	DuplicateAdvertiser Verdict = "duplicate_advertiser"
+	NotInDeal           Verdict = "not_in_deal"
+)
+
+// InactiveVerdict returns why the inactive campaign was deactivated, when it can be told.
+func (c Candidate) InactiveVerdict(now time.Time) Verdict {
+	switch {
+	case !c.ExpiresAt.IsZero() && !c.ExpiresAt.After(now):
+		return Expired
+	case c.ImpressionGoal > 0 && c.Stats.Deliveries >= c.ImpressionGoal:
+		return GoalReached
+	case c.Budgeted() && c.UnitCost().GreaterThan(c.Budget):
+		return OutOfBudget
+	}
+	return Inactive
+}
+
+// CandidateExplanation is the outcome of a candidate in a delivery request. Rank is its position,
+// from 1, in the ranking of the candidates, and ClearingPrice is only set for the winners.
+type CandidateExplanation struct {
+	CampaignMatch
+	ECPM    decimal.Decimal
+	Rank    int
+	Verdict Verdict
+}
+
+// Explanation details a delivery request without delivering it: the floor applied,
+// the verdict of every candidate in ranking order and, when nothing wins, the reason why.
+type Explanation struct {
+	Floor         decimal.Decimal
+	Candidates    []CandidateExplanation
+	NoMatchReason NoMatchReason
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+)
+
+// FrequencyCap limits how many times a campaign is delivered to the same user
+// within a period, e.g. 3 per 24h. A zero cap does not limit the deliveries.
+type FrequencyCap struct {
+	Impressions int
+	Period      time.Duration
+}
+
+// Sequence makes a campaign part of an ordered story: it is only delivered to the users
+// who were delivered the After campaign, at least MinDelay earlier.
+type Sequence struct {
+	After    string
+	MinDelay time.Duration
+}
+
+// Exposures is the delivery history of a user, where each campaign ID maps
+// to the times it was delivered to the user, from the oldest to the latest.
+type Exposures map[string][]time.Time
+
+// Count returns how many times the campaign was delivered to the user after since.
+func (e Exposures) Count(campaignID string, since time.Time) int {
+	count := 0
+	for _, at := range e[campaignID] {
+		if at.After(since) {
+			count++
+		}
+	}
+	return count
+}
+
+// Latest returns when the campaign was last delivered to the user, false if it never was.
+func (e Exposures) Latest(campaignID string) (time.Time, bool) {
+	times := e[campaignID]
+	if len(times) == 0 {
+		return time.Time{}, false
+	}
+	return times[len(times)-1], true
+}
+
+// Reached tells whether the cap of the campaign is reached by the exposures of the user at now.
+func (f FrequencyCap) Reached(campaignID string, exposures Exposures, now time.Time) bool {
+	if f.Impressions <= 0 {
+		return false
+	}
+	return exposures.Count(campaignID, now.Add(-f.Period)) >= f.Impressions
+}
+
+// RecencyCapped tells whether the campaign was delivered to the user less than recencyCap
+// before now. A zero recency cap does not limit the deliveries.
+func (e Exposures) RecencyCapped(campaignID string, recencyCap time.Duration, now time.Time) bool {
+	if recencyCap <= 0 {
+		return false
+	}
+	latest, ok := e.Latest(campaignID)
+	return ok && now.Sub(latest) < recencyCap
+}
+
+// Allows tells whether the exposures of the user let the sequenced campaign be delivered at now.
+// Campaigns without sequence are always allowed.
+func (s Sequence) Allows(exposures Exposures, now time.Time) bool {
+	if s.After == "" {
+		return true
+	}
+	latest, ok := exposures.Latest(s.After)
+	return ok && now.Sub(latest) >= s.MinDelay
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// FloorRule sets the minimum bid accepted for a targeting.
+// Empty targeting fields match any value.
+type FloorRule struct {
+	Targeting
+	Floor decimal.Decimal
+}
+
+// FloorRules is the floor-rule table, where each targeting maps to its floor.
+type FloorRules map[Targeting]decimal.Decimal
+
+// Floor returns the floor of the most specific rule matching the targeting,
+// or zero when no rule applies. Country is more specific than device, and device than OS.
+func (f FloorRules) Floor(t Targeting) decimal.Decimal {
+	for _, c := range t.Generalizations() {
+		if floor, ok := f[c]; ok {
+			return floor
+		}
+	}
+	return decimal.Zero
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"slices"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	NoMatchReason string
+)
+
+const (
+	NoActiveCampaign  NoMatchReason = "no_active_campaign"
+	BelowFloor        NoMatchReason = "below_floor"
+	FrequencyCapped   NoMatchReason = "frequency_capped"
+	RecencyCapped     NoMatchReason = "recency_capped"
+	OutOfSequence     NoMatchReason = "out_of_sequence"
+	CompetingCategory NoMatchReason = "competing_category"
+	Paced             NoMatchReason = "paced"
+	NoCreative        NoMatchReason = "no_creative"
+	// NoDeal is the reason of the private auctions without any known deal allowing their publisher.
+	NoDeal NoMatchReason = "no_deal"
+)
+
+// Targeting is the country, device and OS combination a delivery is requested for.
+type Targeting struct {
+	Country Country
+	Device  Device
+	OS      OS
+}
+
+// Generalizations returns the targeting followed by its variants where empty fields match any
+// value, from the most to the least specific. Country is more specific than device, and device than OS.
+// Each variant is returned once, even when fields of the targeting are already empty.
+func (t Targeting) Generalizations() []Targeting {
+	var generalizations []Targeting
+	for _, g := range []Targeting{
+		{t.Country, t.Device, t.OS},
+		{t.Country, t.Device, ""},
+		{t.Country, "", t.OS},
+		{t.Country, "", ""},
+		{"", t.Device, t.OS},
+		{"", t.Device, ""},
+		{"", "", t.OS},
+		{"", "", ""},
+	} {
+		if !slices.Contains(generalizations, g) {
+			generalizations = append(generalizations, g)
+		}
+	}
+	return generalizations
+}
+
+// MatchRequest holds the parameters of a delivery request.
+type MatchRequest struct {
+	Targeting
+	// BidFloor is the minimum bid accepted by the requester, zero when there is none.
+	BidFloor decimal.Decimal
+	// Slots is the number of distinct campaigns to deliver, one when unset.
+	Slots int
+	// UniqueAdvertisers limits the delivery to one campaign per advertiser.
+	UniqueAdvertisers bool
+	// PageViewID identifies the page view the slots belong to, so campaigns competing
+	// with the ones delivered by previous requests of the page view are excluded.
+	PageViewID string
+	// UserID identifies the user for frequency and recency capping and sequencing,
+	// empty when unknown or without consent.
+	UserID string
+	// Publisher identifies the publisher of the inventory, to check it is allowed by the deals.
+	Publisher string
+	// DealIDs lists the deals the inventory is offered through, empty for the open auction.
+	DealIDs []string
+	// Formats and Sizes list the formats and banner sizes the slot accepts, only the campaigns allowing
+	// them with a creative of one of the formats, banners fitting one of the sizes, being delivered.
+	// When both are empty, any campaign is delivered, with its first banner creative if any.
+	Formats []CreativeFormat
+	Sizes   []Size
+	// PrivateAuction restricts the delivery to the deals, without falling back to the open
+	// auction when none of their campaigns can be delivered.
+	PrivateAuction bool
+}
+
+// MatchResult is the outcome of a delivery request: the matched campaigns in bid
+// order or, when nothing can be delivered, the reason why.
+type MatchResult struct {
+	Matches       []CampaignMatch
+	NoMatchReason NoMatchReason
+}
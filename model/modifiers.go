@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// BidModifiers are the multiplicative adjustments of a campaign bid for the targeting
+// of the delivery and its hour of the day, e.g. 1.2 to bid 20% more on iOS.
+// Values without adjustment are not multiplied.
+type BidModifiers struct {
+	Country map[Country]decimal.Decimal
+	Device  map[Device]decimal.Decimal
+	OS      map[OS]decimal.Decimal
+	// Hour adjusts the bid by the hour of the day of the delivery, from 0 to 23.
+	Hour map[int]decimal.Decimal
+}
+
+// Multiplier returns the product of the adjustments applying to the targeting and hour.
+func (m BidModifiers) Multiplier(t Targeting, hour int) decimal.Decimal {
+	multiplier := decimal.NewFromInt(1)
+	if modifier, ok := m.Country[t.Country]; ok {
+		multiplier = multiplier.Mul(modifier)
+	}
+	if modifier, ok := m.Device[t.Device]; ok {
+		multiplier = multiplier.Mul(modifier)
+	}
+	if modifier, ok := m.OS[t.OS]; ok {
+		multiplier = multiplier.Mul(modifier)
+	}
+	if modifier, ok := m.Hour[hour]; ok {
+		multiplier = multiplier.Mul(modifier)
+	}
+	return multiplier
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+)
+
+// PacingTolerance is how far ahead of its expected deliveries, as a fraction of them,
+// an impression goal campaign can get before it stops being selected.
+const PacingTolerance = 0.1
+
+// PacingProbability returns the probability the impression goal campaign is selected at now,
+// comparing its deliveries with the deliveries expected if they were evenly spread between its
+// creation and expiration. Campaigns behind schedule are always selected, and campaigns ahead
+// are selected less and less, up to never at the pacing tolerance.
+func (c Candidate) PacingProbability(now time.Time) float64 {
+	if c.ImpressionGoal <= 0 || !c.ExpiresAt.After(c.CreatedAt) {
+		return 1
+	}
+
+	elapsed := float64(now.Sub(c.CreatedAt)) / float64(c.ExpiresAt.Sub(c.CreatedAt))
+	elapsed = min(max(elapsed, 0), 1)
+	// one delivery is always expected so the campaign can start
+	expected := max(float64(c.ImpressionGoal)*elapsed, 1)
+
+	ahead := float64(c.Stats.Deliveries)/expected - 1
+	if ahead < 0 {
+		return 1
+	}
+	return max(1-ahead/PacingTolerance, 0)
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+type (
+	PricingModel string
+	EventType    string
+)
+
+// REMINDER: also insert the pricing model in map PricingModels whenever
+// a new pricing model is added as a constant.
+const (
+	// CPD charges the bid per delivery, the default when no pricing model is declared.
+	CPD PricingModel = "cpd"
+	// CPM charges the bid per thousand deliveries.
+	CPM PricingModel = "cpm"
+	// CPC charges the bid per click.
+	CPC PricingModel = "cpc"
+	// CPA charges the bid per conversion.
+	CPA PricingModel = "cpa"
+)
+
+var PricingModels = map[string]PricingModel{
+	"cpd": CPD,
+	"cpm": CPM,
+	"cpc": CPC,
+	"cpa": CPA,
+}
+
+// REMINDER: also insert the event type in map EventTypes whenever
+// a new event type is added as a constant.
+const (
+	Click      EventType = "click"
+	Conversion EventType = "conversion"
+)
+
+var EventTypes = map[string]EventType{
+	"click":      Click,
+	"conversion": Conversion,
+}
+
+// Budgeted tells whether the deliveries and events of the campaign are charged to its budget.
+// House campaigns cost nothing, and impression goal campaigns are sold for their goal.
+func (c Campaign) Budgeted() bool {
+	return c.Priority != House && c.ImpressionGoal == 0
+}
+
+// UnitCost returns the most a single billable event costs the campaign budget.
+// Campaigns whose budget is lower than the unit cost cannot be delivered,
+// so campaigns without budget are delivered without budget.
+func (c Campaign) UnitCost() decimal.Decimal {
+	if !c.Budgeted() {
+		return decimal.Zero
+	}
+	if c.PricingModel == CPM {
+		return c.Bid.Shift(-3)
+	}
+	return c.Bid
+}
+
+// DeliveryCost returns the amount deducted from the budget when the campaign
+// is delivered at the clearing price.
+func (c Campaign) DeliveryCost(clearingPrice decimal.Decimal) decimal.Decimal {
+	if !c.Budgeted() {
+		return decimal.Zero
+	}
+	switch c.PricingModel {
+	case CPM:
+		return clearingPrice.Shift(-3)
+	case CPC, CPA:
+		return decimal.Zero
+	default:
+		return clearingPrice
+	}
+}
+
+// DeliveryCostAtCPM returns the amount deducted from the budget when the campaign is delivered
+// at the price per thousand deliveries, like DeliveryCost.
+func (c Campaign) DeliveryCostAtCPM(cpm decimal.Decimal) decimal.Decimal {
+	if !c.Budgeted() || c.PricingModel == CPC || c.PricingModel == CPA {
+		return decimal.Zero
+	}
+	return cpm.Shift(-3)
+}
+
+// EventCost returns the amount deducted from the budget when the event happens
+// for a delivery cleared at the price. Only clicks of CPC campaigns and conversions
+// of CPA campaigns are billable, and never for campaigns without budget.
+func (c Campaign) EventCost(event EventType, clearingPrice decimal.Decimal) decimal.Decimal {
+	if !c.Budgeted() {
+		return decimal.Zero
+	}
+	if (c.PricingModel == CPC && event == Click) || (c.PricingModel == CPA && event == Conversion) {
+		return clearingPrice
+	}
+	return decimal.Zero
+}
@@ -0,0 +1,34 @@
+package model
+
+type (
+	Priority string
+)
+
+// REMINDER: also insert the priority in map Priorities whenever
+// a new priority is added as a constant.
+const (
+	// Guaranteed campaigns, such as sponsorships, win before any auction campaign at their bid.
+	Guaranteed Priority = "guaranteed"
+	// Standard campaigns compete in the auction, the default when no priority is declared.
+	Standard Priority = "standard"
+	// House campaigns cost nothing and only fill the slots no other campaign won.
+	House Priority = "house"
+)
+
+var Priorities = map[string]Priority{
+	"guaranteed": Guaranteed,
+	"standard":   Standard,
+	"house":      House,
+}
+
+// Tier returns the rank of the priority, lower tiers winning first.
+func (p Priority) Tier() int {
+	switch p {
+	case Guaranteed:
+		return 0
+	case House:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,35 @@
+package model
+
+type (
+	RankingStrategy string
+)
+
+// REMINDER: also insert the ranking strategy in map RankingStrategies whenever
+// a new ranking strategy is added as a constant.
+const (
+	HighestBid     RankingStrategy = "highest_bid"
+	WeightedRandom RankingStrategy = "weighted_random"
+	RoundRobin     RankingStrategy = "round_robin"
+)
+
+var RankingStrategies = map[string]RankingStrategy{
+	"highest_bid":     HighestBid,
+	"weighted_random": WeightedRandom,
+	"round_robin":     RoundRobin,
+}
+
+type (
+	ExplorationMode string
+)
+
+// REMINDER: also insert the exploration mode in map ExplorationModes whenever
+// a new exploration mode is added as a constant.
+const (
+	EpsilonGreedy    ExplorationMode = "epsilon_greedy"
+	ThompsonSampling ExplorationMode = "thompson_sampling"
+)
+
+var ExplorationModes = map[string]ExplorationMode{
+	"epsilon_greedy":    EpsilonGreedy,
+	"thompson_sampling": ThompsonSampling,
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Tracking identifies a delivery in its impression, click and conversion tracking URLs,
+// until they expire. CreativeID is the creative delivered, and ClearingPrice the price
+// the billable events of the delivery are charged.
+type Tracking struct {
+	ReservationID string
+	CampaignID    string
+	CreativeID    string
+	ClearingPrice decimal.Decimal
+	ExpiresAt     time.Time
+}
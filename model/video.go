@@ -0,0 +1,29 @@
+package model
+
+// REMINDER: also insert the video event in map VideoEvents whenever
+// a new video event is added as a constant.
+const (
+	Start         EventType = "start"
+	FirstQuartile EventType = "firstQuartile"
+	Midpoint      EventType = "midpoint"
+	ThirdQuartile EventType = "thirdQuartile"
+	Complete      EventType = "complete"
+)
+
+// VideoEvents are the events of the playback of video creatives, named like the VAST tracking events.
+var VideoEvents = map[string]EventType{
+	"start":         Start,
+	"firstQuartile": FirstQuartile,
+	"midpoint":      Midpoint,
+	"thirdQuartile": ThirdQuartile,
+	"complete":      Complete,
+}
+
+// VideoStats holds the counters of the playback events of the video deliveries.
+type VideoStats struct {
+	Starts         int64
+	FirstQuartiles int64
+	Midpoints      int64
+	ThirdQuartiles int64
+	Completes      int64
+}
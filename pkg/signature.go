@@ -0,0 +1,20 @@
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// Sign returns the HMAC-SHA256 signature of the message with the secret, base64url encoded.
+func Sign(secret []byte, message string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(message))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidSignature tells whether the signature is the one of the message with the secret,
+// comparing them in constant time.
+func ValidSignature(secret []byte, message, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, message)), []byte(signature))
+}
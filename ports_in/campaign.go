@@ -4,12 +4,26 @@ import (
 	"context"
 
 	"ad-campaign-delivery/model"
+	"github.com/shopspring/decimal"
 )
 
 //go:generate go run github.com/matryer/moq -out campaign_mock.go -stub . CampaignService
 type CampaignService interface {
 	Create(ctx context.Context, user model.Campaign, activeDays int) error
-	Match(ctx context.Context, country model.Country, device model.Device, os model.OS) (*model.BidLookup, error)
+	Match(ctx context.Context, req model.MatchRequest) (model.MatchResult, error)
+	Explain(ctx context.Context, req model.MatchRequest) (model.Explanation, error)
+	SetFloorRule(ctx context.Context, rule model.FloorRule) error
+	SetDeal(ctx context.Context, deal model.Deal) error
+	AddCreative(ctx context.Context, creative model.Creative) error
+	ListCreatives(ctx context.Context, campaignID string) ([]model.Creative, error)
+	RemoveCreative(ctx context.Context, campaignID, creativeID string) error
+	ReviewCreative(ctx context.Context, campaignID, creativeID string, review model.Review) error
+	ConfirmDelivery(ctx context.Context, reservationID string) error
+	WinDelivery(ctx context.Context, reservationID string, price decimal.Decimal) error
+	ReleaseDelivery(ctx context.Context, reservationID string) error
+	TrackEvent(ctx context.Context, tracking model.Tracking, event model.EventType) (model.Campaign, error)
 
 	DeactivateExpiredCampaigns()
+	ReleaseExpiredDeliveries()
+	DeleteExpiredExposures()
 }
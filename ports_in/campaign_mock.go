@@ -6,6 +6,7 @@ package ports_in
 import (
 	"ad-campaign-delivery/model"
 	"context"
+	"github.com/shopspring/decimal"
 	"sync"
 )
 
@@ -19,15 +20,54 @@ var _ CampaignService = &CampaignServiceMock{}
 //
 //		// make and configure a mocked CampaignService
 //		mockedCampaignService := &CampaignServiceMock{
+//			AddCreativeFunc: func(ctx context.Context, creative model.Creative) error {
+//				panic("mock out the AddCreative method")
+//			},
+//			ConfirmDeliveryFunc: func(ctx context.Context, reservationID string) error {
+//				panic("mock out the ConfirmDelivery method")
+//			},
 //			CreateFunc: func(ctx context.Context, user model.Campaign, activeDays int) error {
 //				panic("mock out the Create method")
 //			},
 //			DeactivateExpiredCampaignsFunc: func()  {
 //				panic("mock out the DeactivateExpiredCampaigns method")
 //			},
-//			MatchFunc: func(ctx context.Context, country model.Country, device model.Device, os model.OS) (*model.BidLookup, error) {
+//			DeleteExpiredExposuresFunc: func()  {
+//				panic("mock out the DeleteExpiredExposures method")
+//			},
+//			ExplainFunc: func(ctx context.Context, req model.MatchRequest) (model.Explanation, error) {
+//				panic("mock out the Explain method")
+//			},
+//			ListCreativesFunc: func(ctx context.Context, campaignID string) ([]model.Creative, error) {
+//				panic("mock out the ListCreatives method")
+//			},
+//			MatchFunc: func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
 //				panic("mock out the Match method")
 //			},
+//			ReleaseDeliveryFunc: func(ctx context.Context, reservationID string) error {
+//				panic("mock out the ReleaseDelivery method")
+//			},
+//			ReleaseExpiredDeliveriesFunc: func()  {
+//				panic("mock out the ReleaseExpiredDeliveries method")
+//			},
+//			RemoveCreativeFunc: func(ctx context.Context, campaignID string, creativeID string) error {
+//				panic("mock out the RemoveCreative method")
+//			},
+//			ReviewCreativeFunc: func(ctx context.Context, campaignID string, creativeID string, review model.Review) error {
+//				panic("mock out the ReviewCreative method")
+//			},
+//			SetDealFunc: func(ctx context.Context, deal model.Deal) error {
+//				panic("mock out the SetDeal method")
+//			},
+//			SetFloorRuleFunc: func(ctx context.Context, rule model.FloorRule) error {
+//				panic("mock out the SetFloorRule method")
+//			},
+//			TrackEventFunc: func(ctx context.Context, tracking model.Tracking, event model.EventType) (model.Campaign, error) {
+//				panic("mock out the TrackEvent method")
+//			},
+//			WinDeliveryFunc: func(ctx context.Context, reservationID string, price decimal.Decimal) error {
+//				panic("mock out the WinDelivery method")
+//			},
 //		}
 //
 //		// use mockedCampaignService in code that requires CampaignService
@@ -35,17 +75,70 @@ var _ CampaignService = &CampaignServiceMock{}
 //
 //	}
 type CampaignServiceMock struct {
+	// AddCreativeFunc mocks the AddCreative method.
+	AddCreativeFunc func(ctx context.Context, creative model.Creative) error
+
+	// ConfirmDeliveryFunc mocks the ConfirmDelivery method.
+	ConfirmDeliveryFunc func(ctx context.Context, reservationID string) error
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, user model.Campaign, activeDays int) error
 
 	// DeactivateExpiredCampaignsFunc mocks the DeactivateExpiredCampaigns method.
 	DeactivateExpiredCampaignsFunc func()
 
+	// DeleteExpiredExposuresFunc mocks the DeleteExpiredExposures method.
+	DeleteExpiredExposuresFunc func()
+
+	// ExplainFunc mocks the Explain method.
+	ExplainFunc func(ctx context.Context, req model.MatchRequest) (model.Explanation, error)
+
+	// ListCreativesFunc mocks the ListCreatives method.
+	ListCreativesFunc func(ctx context.Context, campaignID string) ([]model.Creative, error)
+
 	// MatchFunc mocks the Match method.
-	MatchFunc func(ctx context.Context, country model.Country, device model.Device, os model.OS) (*model.BidLookup, error)
+	MatchFunc func(ctx context.Context, req model.MatchRequest) (model.MatchResult, error)
+
+	// ReleaseDeliveryFunc mocks the ReleaseDelivery method.
+	ReleaseDeliveryFunc func(ctx context.Context, reservationID string) error
+
+	// ReleaseExpiredDeliveriesFunc mocks the ReleaseExpiredDeliveries method.
+	ReleaseExpiredDeliveriesFunc func()
+
+	// RemoveCreativeFunc mocks the RemoveCreative method.
+	RemoveCreativeFunc func(ctx context.Context, campaignID string, creativeID string) error
+
+	// ReviewCreativeFunc mocks the ReviewCreative method.
+	ReviewCreativeFunc func(ctx context.Context, campaignID string, creativeID string, review model.Review) error
+
+	// SetDealFunc mocks the SetDeal method.
+	SetDealFunc func(ctx context.Context, deal model.Deal) error
+
+	// SetFloorRuleFunc mocks the SetFloorRule method.
+	SetFloorRuleFunc func(ctx context.Context, rule model.FloorRule) error
+
+	// TrackEventFunc mocks the TrackEvent method.
+	TrackEventFunc func(ctx context.Context, tracking model.Tracking, event model.EventType) (model.Campaign, error)
+
+	// WinDeliveryFunc mocks the WinDelivery method.
+	WinDeliveryFunc func(ctx context.Context, reservationID string, price decimal.Decimal) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddCreative holds details about calls to the AddCreative method.
+		AddCreative []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Creative is the creative argument value.
+			Creative model.Creative
+		}
+		// ConfirmDelivery holds details about calls to the ConfirmDelivery method.
+		ConfirmDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReservationID is the reservationID argument value.
+			ReservationID string
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// Ctx is the ctx argument value.
@@ -58,21 +151,187 @@ type CampaignServiceMock struct {
 		// DeactivateExpiredCampaigns holds details about calls to the DeactivateExpiredCampaigns method.
 		DeactivateExpiredCampaigns []struct {
 		}
+		// DeleteExpiredExposures holds details about calls to the DeleteExpiredExposures method.
+		DeleteExpiredExposures []struct {
+		}
+		// Explain holds details about calls to the Explain method.
+		Explain []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.MatchRequest
+		}
+		// ListCreatives holds details about calls to the ListCreatives method.
+		ListCreatives []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CampaignID is the campaignID argument value.
+			CampaignID string
+		}
 		// Match holds details about calls to the Match method.
 		Match []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Country is the country argument value.
-			Country model.Country
-			// Device is the device argument value.
-			Device model.Device
-			// Os is the os argument value.
-			Os model.OS
+			// Req is the req argument value.
+			Req model.MatchRequest
+		}
+		// ReleaseDelivery holds details about calls to the ReleaseDelivery method.
+		ReleaseDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReservationID is the reservationID argument value.
+			ReservationID string
+		}
+		// ReleaseExpiredDeliveries holds details about calls to the ReleaseExpiredDeliveries method.
+		ReleaseExpiredDeliveries []struct {
+		}
+		// RemoveCreative holds details about calls to the RemoveCreative method.
+		RemoveCreative []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CampaignID is the campaignID argument value.
+			CampaignID string
+			// CreativeID is the creativeID argument value.
+			CreativeID string
+		}
+		// ReviewCreative holds details about calls to the ReviewCreative method.
+		ReviewCreative []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CampaignID is the campaignID argument value.
+			CampaignID string
+			// CreativeID is the creativeID argument value.
+			CreativeID string
+			// Review is the review argument value.
+			Review model.Review
+		}
+		// SetDeal holds details about calls to the SetDeal method.
+		SetDeal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Deal is the deal argument value.
+			Deal model.Deal
+		}
+		// SetFloorRule holds details about calls to the SetFloorRule method.
+		SetFloorRule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Rule is the rule argument value.
+			Rule model.FloorRule
+		}
+		// TrackEvent holds details about calls to the TrackEvent method.
+		TrackEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Tracking is the tracking argument value.
+			Tracking model.Tracking
+			// Event is the event argument value.
+			Event model.EventType
+		}
+		// WinDelivery holds details about calls to the WinDelivery method.
+		WinDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReservationID is the reservationID argument value.
+			ReservationID string
+			// Price is the price argument value.
+			Price decimal.Decimal
 		}
 	}
+	lockAddCreative                sync.RWMutex
+	lockConfirmDelivery            sync.RWMutex
 	lockCreate                     sync.RWMutex
 	lockDeactivateExpiredCampaigns sync.RWMutex
+	lockDeleteExpiredExposures     sync.RWMutex
+	lockExplain                    sync.RWMutex
+	lockListCreatives              sync.RWMutex
 	lockMatch                      sync.RWMutex
+	lockReleaseDelivery            sync.RWMutex
+	lockReleaseExpiredDeliveries   sync.RWMutex
+	lockRemoveCreative             sync.RWMutex
+	lockReviewCreative             sync.RWMutex
+	lockSetDeal                    sync.RWMutex
+	lockSetFloorRule               sync.RWMutex
+	lockTrackEvent                 sync.RWMutex
+	lockWinDelivery                sync.RWMutex
+}
+
+// AddCreative calls AddCreativeFunc.
+func (mock *CampaignServiceMock) AddCreative(ctx context.Context, creative model.Creative) error {
+	callInfo := struct {
+		Ctx      context.Context
+		Creative model.Creative
+	}{
+		Ctx:      ctx,
+		Creative: creative,
+	}
+	mock.lockAddCreative.Lock()
+	mock.calls.AddCreative = append(mock.calls.AddCreative, callInfo)
+	mock.lockAddCreative.Unlock()
+	if mock.AddCreativeFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.AddCreativeFunc(ctx, creative)
+}
+
+// AddCreativeCalls gets all the calls that were made to AddCreative.
+// Check the length with:
+//
+//	len(mockedCampaignService.AddCreativeCalls())
+func (mock *CampaignServiceMock) AddCreativeCalls() []struct {
+	Ctx      context.Context
+	Creative model.Creative
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Creative model.Creative
+	}
+	mock.lockAddCreative.RLock()
+	calls = mock.calls.AddCreative
+	mock.lockAddCreative.RUnlock()
+	return calls
+}
+
+// ConfirmDelivery calls ConfirmDeliveryFunc.
+func (mock *CampaignServiceMock) ConfirmDelivery(ctx context.Context, reservationID string) error {
+	callInfo := struct {
+		Ctx           context.Context
+		ReservationID string
+	}{
+		Ctx:           ctx,
+		ReservationID: reservationID,
+	}
+	mock.lockConfirmDelivery.Lock()
+	mock.calls.ConfirmDelivery = append(mock.calls.ConfirmDelivery, callInfo)
+	mock.lockConfirmDelivery.Unlock()
+	if mock.ConfirmDeliveryFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.ConfirmDeliveryFunc(ctx, reservationID)
+}
+
+// ConfirmDeliveryCalls gets all the calls that were made to ConfirmDelivery.
+// Check the length with:
+//
+//	len(mockedCampaignService.ConfirmDeliveryCalls())
+func (mock *CampaignServiceMock) ConfirmDeliveryCalls() []struct {
+	Ctx           context.Context
+	ReservationID string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ReservationID string
+	}
+	mock.lockConfirmDelivery.RLock()
+	calls = mock.calls.ConfirmDelivery
+	mock.lockConfirmDelivery.RUnlock()
+	return calls
 }
 
 // Create calls CreateFunc.
@@ -145,30 +404,133 @@ func (mock *CampaignServiceMock) DeactivateExpiredCampaignsCalls() []struct {
 	return calls
 }
 
+// DeleteExpiredExposures calls DeleteExpiredExposuresFunc.
+func (mock *CampaignServiceMock) DeleteExpiredExposures() {
+	callInfo := struct {
+	}{}
+	mock.lockDeleteExpiredExposures.Lock()
+	mock.calls.DeleteExpiredExposures = append(mock.calls.DeleteExpiredExposures, callInfo)
+	mock.lockDeleteExpiredExposures.Unlock()
+	if mock.DeleteExpiredExposuresFunc == nil {
+		return
+	}
+	mock.DeleteExpiredExposuresFunc()
+}
+
+// DeleteExpiredExposuresCalls gets all the calls that were made to DeleteExpiredExposures.
+// Check the length with:
+//
+//	len(mockedCampaignService.DeleteExpiredExposuresCalls())
+func (mock *CampaignServiceMock) DeleteExpiredExposuresCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockDeleteExpiredExposures.RLock()
+	calls = mock.calls.DeleteExpiredExposures
+	mock.lockDeleteExpiredExposures.RUnlock()
+	return calls
+}
+
+// Explain calls ExplainFunc.
+func (mock *CampaignServiceMock) Explain(ctx context.Context, req model.MatchRequest) (model.Explanation, error) {
+	callInfo := struct {
+		Ctx context.Context
+		Req model.MatchRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockExplain.Lock()
+	mock.calls.Explain = append(mock.calls.Explain, callInfo)
+	mock.lockExplain.Unlock()
+	if mock.ExplainFunc == nil {
+		var (
+			explanationOut model.Explanation
+			errOut         error
+		)
+		return explanationOut, errOut
+	}
+	return mock.ExplainFunc(ctx, req)
+}
+
+// ExplainCalls gets all the calls that were made to Explain.
+// Check the length with:
+//
+//	len(mockedCampaignService.ExplainCalls())
+func (mock *CampaignServiceMock) ExplainCalls() []struct {
+	Ctx context.Context
+	Req model.MatchRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.MatchRequest
+	}
+	mock.lockExplain.RLock()
+	calls = mock.calls.Explain
+	mock.lockExplain.RUnlock()
+	return calls
+}
+
+// ListCreatives calls ListCreativesFunc.
+func (mock *CampaignServiceMock) ListCreatives(ctx context.Context, campaignID string) ([]model.Creative, error) {
+	callInfo := struct {
+		Ctx        context.Context
+		CampaignID string
+	}{
+		Ctx:        ctx,
+		CampaignID: campaignID,
+	}
+	mock.lockListCreatives.Lock()
+	mock.calls.ListCreatives = append(mock.calls.ListCreatives, callInfo)
+	mock.lockListCreatives.Unlock()
+	if mock.ListCreativesFunc == nil {
+		var (
+			creativesOut []model.Creative
+			errOut       error
+		)
+		return creativesOut, errOut
+	}
+	return mock.ListCreativesFunc(ctx, campaignID)
+}
+
+// ListCreativesCalls gets all the calls that were made to ListCreatives.
+// Check the length with:
+//
+//	len(mockedCampaignService.ListCreativesCalls())
+func (mock *CampaignServiceMock) ListCreativesCalls() []struct {
+	Ctx        context.Context
+	CampaignID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		CampaignID string
+	}
+	mock.lockListCreatives.RLock()
+	calls = mock.calls.ListCreatives
+	mock.lockListCreatives.RUnlock()
+	return calls
+}
+
 // Match calls MatchFunc.
-func (mock *CampaignServiceMock) Match(ctx context.Context, country model.Country, device model.Device, os model.OS) (*model.BidLookup, error) {
+func (mock *CampaignServiceMock) Match(ctx context.Context, req model.MatchRequest) (model.MatchResult, error) {
 	callInfo := struct {
-		Ctx     context.Context
-		Country model.Country
-		Device  model.Device
-		Os      model.OS
+		Ctx context.Context
+		Req model.MatchRequest
 	}{
-		Ctx:     ctx,
-		Country: country,
-		Device:  device,
-		Os:      os,
+		Ctx: ctx,
+		Req: req,
 	}
 	mock.lockMatch.Lock()
 	mock.calls.Match = append(mock.calls.Match, callInfo)
 	mock.lockMatch.Unlock()
 	if mock.MatchFunc == nil {
 		var (
-			bidLookupOut *model.BidLookup
-			errOut       error
+			matchResultOut model.MatchResult
+			errOut         error
 		)
-		return bidLookupOut, errOut
+		return matchResultOut, errOut
 	}
-	return mock.MatchFunc(ctx, country, device, os)
+	return mock.MatchFunc(ctx, req)
 }
 
 // MatchCalls gets all the calls that were made to Match.
@@ -176,19 +538,336 @@ func (mock *CampaignServiceMock) Match(ctx context.Context, country model.Countr
 //
 //	len(mockedCampaignService.MatchCalls())
 func (mock *CampaignServiceMock) MatchCalls() []struct {
-	Ctx     context.Context
-	Country model.Country
-	Device  model.Device
-	Os      model.OS
+	Ctx context.Context
+	Req model.MatchRequest
 } {
 	var calls []struct {
-		Ctx     context.Context
-		Country model.Country
-		Device  model.Device
-		Os      model.OS
+		Ctx context.Context
+		Req model.MatchRequest
 	}
 	mock.lockMatch.RLock()
 	calls = mock.calls.Match
 	mock.lockMatch.RUnlock()
 	return calls
 }
+
+// ReleaseDelivery calls ReleaseDeliveryFunc.
+func (mock *CampaignServiceMock) ReleaseDelivery(ctx context.Context, reservationID string) error {
+	callInfo := struct {
+		Ctx           context.Context
+		ReservationID string
+	}{
+		Ctx:           ctx,
+		ReservationID: reservationID,
+	}
+	mock.lockReleaseDelivery.Lock()
+	mock.calls.ReleaseDelivery = append(mock.calls.ReleaseDelivery, callInfo)
+	mock.lockReleaseDelivery.Unlock()
+	if mock.ReleaseDeliveryFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.ReleaseDeliveryFunc(ctx, reservationID)
+}
+
+// ReleaseDeliveryCalls gets all the calls that were made to ReleaseDelivery.
+// Check the length with:
+//
+//	len(mockedCampaignService.ReleaseDeliveryCalls())
+func (mock *CampaignServiceMock) ReleaseDeliveryCalls() []struct {
+	Ctx           context.Context
+	ReservationID string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ReservationID string
+	}
+	mock.lockReleaseDelivery.RLock()
+	calls = mock.calls.ReleaseDelivery
+	mock.lockReleaseDelivery.RUnlock()
+	return calls
+}
+
+// ReleaseExpiredDeliveries calls ReleaseExpiredDeliveriesFunc.
+func (mock *CampaignServiceMock) ReleaseExpiredDeliveries() {
+	callInfo := struct {
+	}{}
+	mock.lockReleaseExpiredDeliveries.Lock()
+	mock.calls.ReleaseExpiredDeliveries = append(mock.calls.ReleaseExpiredDeliveries, callInfo)
+	mock.lockReleaseExpiredDeliveries.Unlock()
+	if mock.ReleaseExpiredDeliveriesFunc == nil {
+		return
+	}
+	mock.ReleaseExpiredDeliveriesFunc()
+}
+
+// ReleaseExpiredDeliveriesCalls gets all the calls that were made to ReleaseExpiredDeliveries.
+// Check the length with:
+//
+//	len(mockedCampaignService.ReleaseExpiredDeliveriesCalls())
+func (mock *CampaignServiceMock) ReleaseExpiredDeliveriesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockReleaseExpiredDeliveries.RLock()
+	calls = mock.calls.ReleaseExpiredDeliveries
+	mock.lockReleaseExpiredDeliveries.RUnlock()
+	return calls
+}
+
+// RemoveCreative calls RemoveCreativeFunc.
+func (mock *CampaignServiceMock) RemoveCreative(ctx context.Context, campaignID string, creativeID string) error {
+	callInfo := struct {
+		Ctx        context.Context
+		CampaignID string
+		CreativeID string
+	}{
+		Ctx:        ctx,
+		CampaignID: campaignID,
+		CreativeID: creativeID,
+	}
+	mock.lockRemoveCreative.Lock()
+	mock.calls.RemoveCreative = append(mock.calls.RemoveCreative, callInfo)
+	mock.lockRemoveCreative.Unlock()
+	if mock.RemoveCreativeFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.RemoveCreativeFunc(ctx, campaignID, creativeID)
+}
+
+// RemoveCreativeCalls gets all the calls that were made to RemoveCreative.
+// Check the length with:
+//
+//	len(mockedCampaignService.RemoveCreativeCalls())
+func (mock *CampaignServiceMock) RemoveCreativeCalls() []struct {
+	Ctx        context.Context
+	CampaignID string
+	CreativeID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		CampaignID string
+		CreativeID string
+	}
+	mock.lockRemoveCreative.RLock()
+	calls = mock.calls.RemoveCreative
+	mock.lockRemoveCreative.RUnlock()
+	return calls
+}
+
+// ReviewCreative calls ReviewCreativeFunc.
+func (mock *CampaignServiceMock) ReviewCreative(ctx context.Context, campaignID string, creativeID string, review model.Review) error {
+	callInfo := struct {
+		Ctx        context.Context
+		CampaignID string
+		CreativeID string
+		Review     model.Review
+	}{
+		Ctx:        ctx,
+		CampaignID: campaignID,
+		CreativeID: creativeID,
+		Review:     review,
+	}
+	mock.lockReviewCreative.Lock()
+	mock.calls.ReviewCreative = append(mock.calls.ReviewCreative, callInfo)
+	mock.lockReviewCreative.Unlock()
+	if mock.ReviewCreativeFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.ReviewCreativeFunc(ctx, campaignID, creativeID, review)
+}
+
+// ReviewCreativeCalls gets all the calls that were made to ReviewCreative.
+// Check the length with:
+//
+//	len(mockedCampaignService.ReviewCreativeCalls())
+func (mock *CampaignServiceMock) ReviewCreativeCalls() []struct {
+	Ctx        context.Context
+	CampaignID string
+	CreativeID string
+	Review     model.Review
+} {
+	var calls []struct {
+		Ctx        context.Context
+		CampaignID string
+		CreativeID string
+		Review     model.Review
+	}
+	mock.lockReviewCreative.RLock()
+	calls = mock.calls.ReviewCreative
+	mock.lockReviewCreative.RUnlock()
+	return calls
+}
+
+// SetDeal calls SetDealFunc.
+func (mock *CampaignServiceMock) SetDeal(ctx context.Context, deal model.Deal) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Deal model.Deal
+	}{
+		Ctx:  ctx,
+		Deal: deal,
+	}
+	mock.lockSetDeal.Lock()
+	mock.calls.SetDeal = append(mock.calls.SetDeal, callInfo)
+	mock.lockSetDeal.Unlock()
+	if mock.SetDealFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetDealFunc(ctx, deal)
+}
+
+// SetDealCalls gets all the calls that were made to SetDeal.
+// Check the length with:
+//
+//	len(mockedCampaignService.SetDealCalls())
+func (mock *CampaignServiceMock) SetDealCalls() []struct {
+	Ctx  context.Context
+	Deal model.Deal
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Deal model.Deal
+	}
+	mock.lockSetDeal.RLock()
+	calls = mock.calls.SetDeal
+	mock.lockSetDeal.RUnlock()
+	return calls
+}
+
+// SetFloorRule calls SetFloorRuleFunc.
+func (mock *CampaignServiceMock) SetFloorRule(ctx context.Context, rule model.FloorRule) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Rule model.FloorRule
+	}{
+		Ctx:  ctx,
+		Rule: rule,
+	}
+	mock.lockSetFloorRule.Lock()
+	mock.calls.SetFloorRule = append(mock.calls.SetFloorRule, callInfo)
+	mock.lockSetFloorRule.Unlock()
+	if mock.SetFloorRuleFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetFloorRuleFunc(ctx, rule)
+}
+
+// SetFloorRuleCalls gets all the calls that were made to SetFloorRule.
+// Check the length with:
+//
+//	len(mockedCampaignService.SetFloorRuleCalls())
+func (mock *CampaignServiceMock) SetFloorRuleCalls() []struct {
+	Ctx  context.Context
+	Rule model.FloorRule
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Rule model.FloorRule
+	}
+	mock.lockSetFloorRule.RLock()
+	calls = mock.calls.SetFloorRule
+	mock.lockSetFloorRule.RUnlock()
+	return calls
+}
+
+// TrackEvent calls TrackEventFunc.
+func (mock *CampaignServiceMock) TrackEvent(ctx context.Context, tracking model.Tracking, event model.EventType) (model.Campaign, error) {
+	callInfo := struct {
+		Ctx      context.Context
+		Tracking model.Tracking
+		Event    model.EventType
+	}{
+		Ctx:      ctx,
+		Tracking: tracking,
+		Event:    event,
+	}
+	mock.lockTrackEvent.Lock()
+	mock.calls.TrackEvent = append(mock.calls.TrackEvent, callInfo)
+	mock.lockTrackEvent.Unlock()
+	if mock.TrackEventFunc == nil {
+		var (
+			campaignOut model.Campaign
+			errOut      error
+		)
+		return campaignOut, errOut
+	}
+	return mock.TrackEventFunc(ctx, tracking, event)
+}
+
+// TrackEventCalls gets all the calls that were made to TrackEvent.
+// Check the length with:
+//
+//	len(mockedCampaignService.TrackEventCalls())
+func (mock *CampaignServiceMock) TrackEventCalls() []struct {
+	Ctx      context.Context
+	Tracking model.Tracking
+	Event    model.EventType
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Tracking model.Tracking
+		Event    model.EventType
+	}
+	mock.lockTrackEvent.RLock()
+	calls = mock.calls.TrackEvent
+	mock.lockTrackEvent.RUnlock()
+	return calls
+}
+
+// WinDelivery calls WinDeliveryFunc.
+func (mock *CampaignServiceMock) WinDelivery(ctx context.Context, reservationID string, price decimal.Decimal) error {
+	callInfo := struct {
+		Ctx           context.Context
+		ReservationID string
+		Price         decimal.Decimal
+	}{
+		Ctx:           ctx,
+		ReservationID: reservationID,
+		Price:         price,
+	}
+	mock.lockWinDelivery.Lock()
+	mock.calls.WinDelivery = append(mock.calls.WinDelivery, callInfo)
+	mock.lockWinDelivery.Unlock()
+	if mock.WinDeliveryFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.WinDeliveryFunc(ctx, reservationID, price)
+}
+
+// WinDeliveryCalls gets all the calls that were made to WinDelivery.
+// Check the length with:
+//
+//	len(mockedCampaignService.WinDeliveryCalls())
+func (mock *CampaignServiceMock) WinDeliveryCalls() []struct {
+	Ctx           context.Context
+	ReservationID string
+	Price         decimal.Decimal
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ReservationID string
+		Price         decimal.Decimal
+	}
+	mock.lockWinDelivery.RLock()
+	calls = mock.calls.WinDelivery
+	mock.lockWinDelivery.RUnlock()
+	return calls
+}
@@ -3,10 +3,26 @@ package ports_out
 import (
 	"ad-campaign-delivery/model"
 	"context"
+
+	"github.com/shopspring/decimal"
 )
 //go:generate go run github.com/matryer/moq -out campaign_mock.go -stub . CampaignRepository
 type CampaignRepository interface {
 	CreateCampaign(ctx context.Context, campaign model.Campaign) error
-	MatchCampaign(ctx context.Context, country model.Country, device model.Device, os model.OS) (*model.BidLookup, error)
+	FindCandidates(ctx context.Context, targeting model.Targeting, formats []model.CreativeFormat, sizes []model.Size) ([]model.Candidate, error)
+	FindFloor(ctx context.Context, targeting model.Targeting) (decimal.Decimal, error)
+	DeliverCampaigns(ctx context.Context, deliveries []model.Delivery) error
+	ConfirmDelivery(ctx context.Context, reservationID string) error
+	WinDelivery(ctx context.Context, reservationID string, price decimal.Decimal) error
+	ReleaseDelivery(ctx context.Context, reservationID string) (model.Delivery, error)
+	SaveFloorRule(ctx context.Context, rule model.FloorRule) error
+	SaveDeal(ctx context.Context, deal model.Deal) error
+	FindDeals(ctx context.Context, ids []string) ([]model.Deal, error)
+	CreateCreative(ctx context.Context, creative model.Creative) error
+	FindCreatives(ctx context.Context, campaignID string) ([]model.Creative, error)
+	DeleteCreative(ctx context.Context, campaignID, creativeID string) error
+	UpdateCreativeReview(ctx context.Context, campaignID, creativeID string, review model.Review) error
+	TrackEvent(ctx context.Context, tracking model.Tracking, event model.EventType) (model.Campaign, error)
 	DeactivateExpiredCampaigns()
+	ReleaseExpiredDeliveries() []model.Delivery
 }
@@ -6,6 +6,7 @@ package ports_out
 import (
 	"ad-campaign-delivery/model"
 	"context"
+	"github.com/shopspring/decimal"
 	"sync"
 )
 
@@ -19,14 +20,56 @@ var _ CampaignRepository = &CampaignRepositoryMock{}
 //
 //		// make and configure a mocked CampaignRepository
 //		mockedCampaignRepository := &CampaignRepositoryMock{
+//			ConfirmDeliveryFunc: func(ctx context.Context, reservationID string) error {
+//				panic("mock out the ConfirmDelivery method")
+//			},
 //			CreateCampaignFunc: func(ctx context.Context, campaign model.Campaign) error {
 //				panic("mock out the CreateCampaign method")
 //			},
+//			CreateCreativeFunc: func(ctx context.Context, creative model.Creative) error {
+//				panic("mock out the CreateCreative method")
+//			},
 //			DeactivateExpiredCampaignsFunc: func()  {
 //				panic("mock out the DeactivateExpiredCampaigns method")
 //			},
-//			MatchCampaignFunc: func(ctx context.Context, country model.Country, device model.Device, os model.OS) (*model.BidLookup, error) {
-//				panic("mock out the MatchCampaign method")
+//			DeleteCreativeFunc: func(ctx context.Context, campaignID string, creativeID string) error {
+//				panic("mock out the DeleteCreative method")
+//			},
+//			DeliverCampaignsFunc: func(ctx context.Context, deliveries []model.Delivery) error {
+//				panic("mock out the DeliverCampaigns method")
+//			},
+//			FindCandidatesFunc: func(ctx context.Context, targeting model.Targeting, formats []model.CreativeFormat, sizes []model.Size) ([]model.Candidate, error) {
+//				panic("mock out the FindCandidates method")
+//			},
+//			FindCreativesFunc: func(ctx context.Context, campaignID string) ([]model.Creative, error) {
+//				panic("mock out the FindCreatives method")
+//			},
+//			FindDealsFunc: func(ctx context.Context, ids []string) ([]model.Deal, error) {
+//				panic("mock out the FindDeals method")
+//			},
+//			FindFloorFunc: func(ctx context.Context, targeting model.Targeting) (decimal.Decimal, error) {
+//				panic("mock out the FindFloor method")
+//			},
+//			ReleaseDeliveryFunc: func(ctx context.Context, reservationID string) (model.Delivery, error) {
+//				panic("mock out the ReleaseDelivery method")
+//			},
+//			ReleaseExpiredDeliveriesFunc: func() []model.Delivery {
+//				panic("mock out the ReleaseExpiredDeliveries method")
+//			},
+//			SaveDealFunc: func(ctx context.Context, deal model.Deal) error {
+//				panic("mock out the SaveDeal method")
+//			},
+//			SaveFloorRuleFunc: func(ctx context.Context, rule model.FloorRule) error {
+//				panic("mock out the SaveFloorRule method")
+//			},
+//			TrackEventFunc: func(ctx context.Context, tracking model.Tracking, event model.EventType) (model.Campaign, error) {
+//				panic("mock out the TrackEvent method")
+//			},
+//			UpdateCreativeReviewFunc: func(ctx context.Context, campaignID string, creativeID string, review model.Review) error {
+//				panic("mock out the UpdateCreativeReview method")
+//			},
+//			WinDeliveryFunc: func(ctx context.Context, reservationID string, price decimal.Decimal) error {
+//				panic("mock out the WinDelivery method")
 //			},
 //		}
 //
@@ -35,17 +78,66 @@ var _ CampaignRepository = &CampaignRepositoryMock{}
 //
 //	}
 type CampaignRepositoryMock struct {
+	// ConfirmDeliveryFunc mocks the ConfirmDelivery method.
+	ConfirmDeliveryFunc func(ctx context.Context, reservationID string) error
+
 	// CreateCampaignFunc mocks the CreateCampaign method.
 	CreateCampaignFunc func(ctx context.Context, campaign model.Campaign) error
 
+	// CreateCreativeFunc mocks the CreateCreative method.
+	CreateCreativeFunc func(ctx context.Context, creative model.Creative) error
+
 	// DeactivateExpiredCampaignsFunc mocks the DeactivateExpiredCampaigns method.
 	DeactivateExpiredCampaignsFunc func()
 
-	// MatchCampaignFunc mocks the MatchCampaign method.
-	MatchCampaignFunc func(ctx context.Context, country model.Country, device model.Device, os model.OS) (*model.BidLookup, error)
+	// DeleteCreativeFunc mocks the DeleteCreative method.
+	DeleteCreativeFunc func(ctx context.Context, campaignID string, creativeID string) error
+
+	// DeliverCampaignsFunc mocks the DeliverCampaigns method.
+	DeliverCampaignsFunc func(ctx context.Context, deliveries []model.Delivery) error
+
+	// FindCandidatesFunc mocks the FindCandidates method.
+	FindCandidatesFunc func(ctx context.Context, targeting model.Targeting, formats []model.CreativeFormat, sizes []model.Size) ([]model.Candidate, error)
+
+	// FindCreativesFunc mocks the FindCreatives method.
+	FindCreativesFunc func(ctx context.Context, campaignID string) ([]model.Creative, error)
+
+	// FindDealsFunc mocks the FindDeals method.
+	FindDealsFunc func(ctx context.Context, ids []string) ([]model.Deal, error)
+
+	// FindFloorFunc mocks the FindFloor method.
+	FindFloorFunc func(ctx context.Context, targeting model.Targeting) (decimal.Decimal, error)
+
+	// ReleaseDeliveryFunc mocks the ReleaseDelivery method.
+	ReleaseDeliveryFunc func(ctx context.Context, reservationID string) (model.Delivery, error)
+
+	// ReleaseExpiredDeliveriesFunc mocks the ReleaseExpiredDeliveries method.
+	ReleaseExpiredDeliveriesFunc func() []model.Delivery
+
+	// SaveDealFunc mocks the SaveDeal method.
+	SaveDealFunc func(ctx context.Context, deal model.Deal) error
+
+	// SaveFloorRuleFunc mocks the SaveFloorRule method.
+	SaveFloorRuleFunc func(ctx context.Context, rule model.FloorRule) error
+
+	// TrackEventFunc mocks the TrackEvent method.
+	TrackEventFunc func(ctx context.Context, tracking model.Tracking, event model.EventType) (model.Campaign, error)
+
+	// UpdateCreativeReviewFunc mocks the UpdateCreativeReview method.
+	UpdateCreativeReviewFunc func(ctx context.Context, campaignID string, creativeID string, review model.Review) error
+
+	// WinDeliveryFunc mocks the WinDelivery method.
+	WinDeliveryFunc func(ctx context.Context, reservationID string, price decimal.Decimal) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ConfirmDelivery holds details about calls to the ConfirmDelivery method.
+		ConfirmDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReservationID is the reservationID argument value.
+			ReservationID string
+		}
 		// CreateCampaign holds details about calls to the CreateCampaign method.
 		CreateCampaign []struct {
 			// Ctx is the ctx argument value.
@@ -53,24 +145,174 @@ type CampaignRepositoryMock struct {
 			// Campaign is the campaign argument value.
 			Campaign model.Campaign
 		}
+		// CreateCreative holds details about calls to the CreateCreative method.
+		CreateCreative []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Creative is the creative argument value.
+			Creative model.Creative
+		}
 		// DeactivateExpiredCampaigns holds details about calls to the DeactivateExpiredCampaigns method.
 		DeactivateExpiredCampaigns []struct {
 		}
-		// MatchCampaign holds details about calls to the MatchCampaign method.
-		MatchCampaign []struct {
+		// DeleteCreative holds details about calls to the DeleteCreative method.
+		DeleteCreative []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CampaignID is the campaignID argument value.
+			CampaignID string
+			// CreativeID is the creativeID argument value.
+			CreativeID string
+		}
+		// DeliverCampaigns holds details about calls to the DeliverCampaigns method.
+		DeliverCampaigns []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Deliveries is the deliveries argument value.
+			Deliveries []model.Delivery
+		}
+		// FindCandidates holds details about calls to the FindCandidates method.
+		FindCandidates []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Country is the country argument value.
-			Country model.Country
-			// Device is the device argument value.
-			Device model.Device
-			// Os is the os argument value.
-			Os model.OS
+			// Targeting is the targeting argument value.
+			Targeting model.Targeting
+			// Formats is the formats argument value.
+			Formats []model.CreativeFormat
+			// Sizes is the sizes argument value.
+			Sizes []model.Size
+		}
+		// FindCreatives holds details about calls to the FindCreatives method.
+		FindCreatives []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CampaignID is the campaignID argument value.
+			CampaignID string
+		}
+		// FindDeals holds details about calls to the FindDeals method.
+		FindDeals []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []string
+		}
+		// FindFloor holds details about calls to the FindFloor method.
+		FindFloor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Targeting is the targeting argument value.
+			Targeting model.Targeting
+		}
+		// ReleaseDelivery holds details about calls to the ReleaseDelivery method.
+		ReleaseDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReservationID is the reservationID argument value.
+			ReservationID string
+		}
+		// ReleaseExpiredDeliveries holds details about calls to the ReleaseExpiredDeliveries method.
+		ReleaseExpiredDeliveries []struct {
+		}
+		// SaveDeal holds details about calls to the SaveDeal method.
+		SaveDeal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Deal is the deal argument value.
+			Deal model.Deal
+		}
+		// SaveFloorRule holds details about calls to the SaveFloorRule method.
+		SaveFloorRule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Rule is the rule argument value.
+			Rule model.FloorRule
+		}
+		// TrackEvent holds details about calls to the TrackEvent method.
+		TrackEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Tracking is the tracking argument value.
+			Tracking model.Tracking
+			// Event is the event argument value.
+			Event model.EventType
+		}
+		// UpdateCreativeReview holds details about calls to the UpdateCreativeReview method.
+		UpdateCreativeReview []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CampaignID is the campaignID argument value.
+			CampaignID string
+			// CreativeID is the creativeID argument value.
+			CreativeID string
+			// Review is the review argument value.
+			Review model.Review
+		}
+		// WinDelivery holds details about calls to the WinDelivery method.
+		WinDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReservationID is the reservationID argument value.
+			ReservationID string
+			// Price is the price argument value.
+			Price decimal.Decimal
 		}
 	}
+	lockConfirmDelivery            sync.RWMutex
 	lockCreateCampaign             sync.RWMutex
+	lockCreateCreative             sync.RWMutex
 	lockDeactivateExpiredCampaigns sync.RWMutex
-	lockMatchCampaign              sync.RWMutex
+	lockDeleteCreative             sync.RWMutex
+	lockDeliverCampaigns           sync.RWMutex
+	lockFindCandidates             sync.RWMutex
+	lockFindCreatives              sync.RWMutex
+	lockFindDeals                  sync.RWMutex
+	lockFindFloor                  sync.RWMutex
+	lockReleaseDelivery            sync.RWMutex
+	lockReleaseExpiredDeliveries   sync.RWMutex
+	lockSaveDeal                   sync.RWMutex
+	lockSaveFloorRule              sync.RWMutex
+	lockTrackEvent                 sync.RWMutex
+	lockUpdateCreativeReview       sync.RWMutex
+	lockWinDelivery                sync.RWMutex
+}
+
+// ConfirmDelivery calls ConfirmDeliveryFunc.
+func (mock *CampaignRepositoryMock) ConfirmDelivery(ctx context.Context, reservationID string) error {
+	callInfo := struct {
+		Ctx           context.Context
+		ReservationID string
+	}{
+		Ctx:           ctx,
+		ReservationID: reservationID,
+	}
+	mock.lockConfirmDelivery.Lock()
+	mock.calls.ConfirmDelivery = append(mock.calls.ConfirmDelivery, callInfo)
+	mock.lockConfirmDelivery.Unlock()
+	if mock.ConfirmDeliveryFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.ConfirmDeliveryFunc(ctx, reservationID)
+}
+
+// ConfirmDeliveryCalls gets all the calls that were made to ConfirmDelivery.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.ConfirmDeliveryCalls())
+func (mock *CampaignRepositoryMock) ConfirmDeliveryCalls() []struct {
+	Ctx           context.Context
+	ReservationID string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ReservationID string
+	}
+	mock.lockConfirmDelivery.RLock()
+	calls = mock.calls.ConfirmDelivery
+	mock.lockConfirmDelivery.RUnlock()
+	return calls
 }
 
 // CreateCampaign calls CreateCampaignFunc.
@@ -112,6 +354,45 @@ func (mock *CampaignRepositoryMock) CreateCampaignCalls() []struct {
 	return calls
 }
 
+// CreateCreative calls CreateCreativeFunc.
+func (mock *CampaignRepositoryMock) CreateCreative(ctx context.Context, creative model.Creative) error {
+	callInfo := struct {
+		Ctx      context.Context
+		Creative model.Creative
+	}{
+		Ctx:      ctx,
+		Creative: creative,
+	}
+	mock.lockCreateCreative.Lock()
+	mock.calls.CreateCreative = append(mock.calls.CreateCreative, callInfo)
+	mock.lockCreateCreative.Unlock()
+	if mock.CreateCreativeFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.CreateCreativeFunc(ctx, creative)
+}
+
+// CreateCreativeCalls gets all the calls that were made to CreateCreative.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.CreateCreativeCalls())
+func (mock *CampaignRepositoryMock) CreateCreativeCalls() []struct {
+	Ctx      context.Context
+	Creative model.Creative
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Creative model.Creative
+	}
+	mock.lockCreateCreative.RLock()
+	calls = mock.calls.CreateCreative
+	mock.lockCreateCreative.RUnlock()
+	return calls
+}
+
 // DeactivateExpiredCampaigns calls DeactivateExpiredCampaignsFunc.
 func (mock *CampaignRepositoryMock) DeactivateExpiredCampaigns() {
 	callInfo := struct {
@@ -139,50 +420,534 @@ func (mock *CampaignRepositoryMock) DeactivateExpiredCampaignsCalls() []struct {
 	return calls
 }
 
-// MatchCampaign calls MatchCampaignFunc.
-func (mock *CampaignRepositoryMock) MatchCampaign(ctx context.Context, country model.Country, device model.Device, os model.OS) (*model.BidLookup, error) {
+// DeleteCreative calls DeleteCreativeFunc.
+func (mock *CampaignRepositoryMock) DeleteCreative(ctx context.Context, campaignID string, creativeID string) error {
 	callInfo := struct {
-		Ctx     context.Context
-		Country model.Country
-		Device  model.Device
-		Os      model.OS
+		Ctx        context.Context
+		CampaignID string
+		CreativeID string
 	}{
-		Ctx:     ctx,
-		Country: country,
-		Device:  device,
-		Os:      os,
+		Ctx:        ctx,
+		CampaignID: campaignID,
+		CreativeID: creativeID,
 	}
-	mock.lockMatchCampaign.Lock()
-	mock.calls.MatchCampaign = append(mock.calls.MatchCampaign, callInfo)
-	mock.lockMatchCampaign.Unlock()
-	if mock.MatchCampaignFunc == nil {
+	mock.lockDeleteCreative.Lock()
+	mock.calls.DeleteCreative = append(mock.calls.DeleteCreative, callInfo)
+	mock.lockDeleteCreative.Unlock()
+	if mock.DeleteCreativeFunc == nil {
 		var (
-			bidLookupOut *model.BidLookup
+			errOut error
+		)
+		return errOut
+	}
+	return mock.DeleteCreativeFunc(ctx, campaignID, creativeID)
+}
+
+// DeleteCreativeCalls gets all the calls that were made to DeleteCreative.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.DeleteCreativeCalls())
+func (mock *CampaignRepositoryMock) DeleteCreativeCalls() []struct {
+	Ctx        context.Context
+	CampaignID string
+	CreativeID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		CampaignID string
+		CreativeID string
+	}
+	mock.lockDeleteCreative.RLock()
+	calls = mock.calls.DeleteCreative
+	mock.lockDeleteCreative.RUnlock()
+	return calls
+}
+
+// DeliverCampaigns calls DeliverCampaignsFunc.
+func (mock *CampaignRepositoryMock) DeliverCampaigns(ctx context.Context, deliveries []model.Delivery) error {
+	callInfo := struct {
+		Ctx        context.Context
+		Deliveries []model.Delivery
+	}{
+		Ctx:        ctx,
+		Deliveries: deliveries,
+	}
+	mock.lockDeliverCampaigns.Lock()
+	mock.calls.DeliverCampaigns = append(mock.calls.DeliverCampaigns, callInfo)
+	mock.lockDeliverCampaigns.Unlock()
+	if mock.DeliverCampaignsFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.DeliverCampaignsFunc(ctx, deliveries)
+}
+
+// DeliverCampaignsCalls gets all the calls that were made to DeliverCampaigns.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.DeliverCampaignsCalls())
+func (mock *CampaignRepositoryMock) DeliverCampaignsCalls() []struct {
+	Ctx        context.Context
+	Deliveries []model.Delivery
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Deliveries []model.Delivery
+	}
+	mock.lockDeliverCampaigns.RLock()
+	calls = mock.calls.DeliverCampaigns
+	mock.lockDeliverCampaigns.RUnlock()
+	return calls
+}
+
+// FindCandidates calls FindCandidatesFunc.
+func (mock *CampaignRepositoryMock) FindCandidates(ctx context.Context, targeting model.Targeting, formats []model.CreativeFormat, sizes []model.Size) ([]model.Candidate, error) {
+	callInfo := struct {
+		Ctx       context.Context
+		Targeting model.Targeting
+		Formats   []model.CreativeFormat
+		Sizes     []model.Size
+	}{
+		Ctx:       ctx,
+		Targeting: targeting,
+		Formats:   formats,
+		Sizes:     sizes,
+	}
+	mock.lockFindCandidates.Lock()
+	mock.calls.FindCandidates = append(mock.calls.FindCandidates, callInfo)
+	mock.lockFindCandidates.Unlock()
+	if mock.FindCandidatesFunc == nil {
+		var (
+			candidatesOut []model.Candidate
+			errOut        error
+		)
+		return candidatesOut, errOut
+	}
+	return mock.FindCandidatesFunc(ctx, targeting, formats, sizes)
+}
+
+// FindCandidatesCalls gets all the calls that were made to FindCandidates.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.FindCandidatesCalls())
+func (mock *CampaignRepositoryMock) FindCandidatesCalls() []struct {
+	Ctx       context.Context
+	Targeting model.Targeting
+	Formats   []model.CreativeFormat
+	Sizes     []model.Size
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Targeting model.Targeting
+		Formats   []model.CreativeFormat
+		Sizes     []model.Size
+	}
+	mock.lockFindCandidates.RLock()
+	calls = mock.calls.FindCandidates
+	mock.lockFindCandidates.RUnlock()
+	return calls
+}
+
+// FindCreatives calls FindCreativesFunc.
+func (mock *CampaignRepositoryMock) FindCreatives(ctx context.Context, campaignID string) ([]model.Creative, error) {
+	callInfo := struct {
+		Ctx        context.Context
+		CampaignID string
+	}{
+		Ctx:        ctx,
+		CampaignID: campaignID,
+	}
+	mock.lockFindCreatives.Lock()
+	mock.calls.FindCreatives = append(mock.calls.FindCreatives, callInfo)
+	mock.lockFindCreatives.Unlock()
+	if mock.FindCreativesFunc == nil {
+		var (
+			creativesOut []model.Creative
 			errOut       error
 		)
-		return bidLookupOut, errOut
+		return creativesOut, errOut
+	}
+	return mock.FindCreativesFunc(ctx, campaignID)
+}
+
+// FindCreativesCalls gets all the calls that were made to FindCreatives.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.FindCreativesCalls())
+func (mock *CampaignRepositoryMock) FindCreativesCalls() []struct {
+	Ctx        context.Context
+	CampaignID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		CampaignID string
+	}
+	mock.lockFindCreatives.RLock()
+	calls = mock.calls.FindCreatives
+	mock.lockFindCreatives.RUnlock()
+	return calls
+}
+
+// FindDeals calls FindDealsFunc.
+func (mock *CampaignRepositoryMock) FindDeals(ctx context.Context, ids []string) ([]model.Deal, error) {
+	callInfo := struct {
+		Ctx context.Context
+		Ids []string
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	mock.lockFindDeals.Lock()
+	mock.calls.FindDeals = append(mock.calls.FindDeals, callInfo)
+	mock.lockFindDeals.Unlock()
+	if mock.FindDealsFunc == nil {
+		var (
+			dealsOut []model.Deal
+			errOut   error
+		)
+		return dealsOut, errOut
+	}
+	return mock.FindDealsFunc(ctx, ids)
+}
+
+// FindDealsCalls gets all the calls that were made to FindDeals.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.FindDealsCalls())
+func (mock *CampaignRepositoryMock) FindDealsCalls() []struct {
+	Ctx context.Context
+	Ids []string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []string
+	}
+	mock.lockFindDeals.RLock()
+	calls = mock.calls.FindDeals
+	mock.lockFindDeals.RUnlock()
+	return calls
+}
+
+// FindFloor calls FindFloorFunc.
+func (mock *CampaignRepositoryMock) FindFloor(ctx context.Context, targeting model.Targeting) (decimal.Decimal, error) {
+	callInfo := struct {
+		Ctx       context.Context
+		Targeting model.Targeting
+	}{
+		Ctx:       ctx,
+		Targeting: targeting,
+	}
+	mock.lockFindFloor.Lock()
+	mock.calls.FindFloor = append(mock.calls.FindFloor, callInfo)
+	mock.lockFindFloor.Unlock()
+	if mock.FindFloorFunc == nil {
+		var (
+			decimalOut decimal.Decimal
+			errOut     error
+		)
+		return decimalOut, errOut
+	}
+	return mock.FindFloorFunc(ctx, targeting)
+}
+
+// FindFloorCalls gets all the calls that were made to FindFloor.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.FindFloorCalls())
+func (mock *CampaignRepositoryMock) FindFloorCalls() []struct {
+	Ctx       context.Context
+	Targeting model.Targeting
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Targeting model.Targeting
+	}
+	mock.lockFindFloor.RLock()
+	calls = mock.calls.FindFloor
+	mock.lockFindFloor.RUnlock()
+	return calls
+}
+
+// ReleaseDelivery calls ReleaseDeliveryFunc.
+func (mock *CampaignRepositoryMock) ReleaseDelivery(ctx context.Context, reservationID string) (model.Delivery, error) {
+	callInfo := struct {
+		Ctx           context.Context
+		ReservationID string
+	}{
+		Ctx:           ctx,
+		ReservationID: reservationID,
+	}
+	mock.lockReleaseDelivery.Lock()
+	mock.calls.ReleaseDelivery = append(mock.calls.ReleaseDelivery, callInfo)
+	mock.lockReleaseDelivery.Unlock()
+	if mock.ReleaseDeliveryFunc == nil {
+		var (
+			deliveryOut model.Delivery
+			errOut      error
+		)
+		return deliveryOut, errOut
+	}
+	return mock.ReleaseDeliveryFunc(ctx, reservationID)
+}
+
+// ReleaseDeliveryCalls gets all the calls that were made to ReleaseDelivery.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.ReleaseDeliveryCalls())
+func (mock *CampaignRepositoryMock) ReleaseDeliveryCalls() []struct {
+	Ctx           context.Context
+	ReservationID string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ReservationID string
+	}
+	mock.lockReleaseDelivery.RLock()
+	calls = mock.calls.ReleaseDelivery
+	mock.lockReleaseDelivery.RUnlock()
+	return calls
+}
+
+// ReleaseExpiredDeliveries calls ReleaseExpiredDeliveriesFunc.
+func (mock *CampaignRepositoryMock) ReleaseExpiredDeliveries() []model.Delivery {
+	callInfo := struct {
+	}{}
+	mock.lockReleaseExpiredDeliveries.Lock()
+	mock.calls.ReleaseExpiredDeliveries = append(mock.calls.ReleaseExpiredDeliveries, callInfo)
+	mock.lockReleaseExpiredDeliveries.Unlock()
+	if mock.ReleaseExpiredDeliveriesFunc == nil {
+		var (
+			deliverysOut []model.Delivery
+		)
+		return deliverysOut
+	}
+	return mock.ReleaseExpiredDeliveriesFunc()
+}
+
+// ReleaseExpiredDeliveriesCalls gets all the calls that were made to ReleaseExpiredDeliveries.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.ReleaseExpiredDeliveriesCalls())
+func (mock *CampaignRepositoryMock) ReleaseExpiredDeliveriesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockReleaseExpiredDeliveries.RLock()
+	calls = mock.calls.ReleaseExpiredDeliveries
+	mock.lockReleaseExpiredDeliveries.RUnlock()
+	return calls
+}
+
+// SaveDeal calls SaveDealFunc.
+func (mock *CampaignRepositoryMock) SaveDeal(ctx context.Context, deal model.Deal) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Deal model.Deal
+	}{
+		Ctx:  ctx,
+		Deal: deal,
+	}
+	mock.lockSaveDeal.Lock()
+	mock.calls.SaveDeal = append(mock.calls.SaveDeal, callInfo)
+	mock.lockSaveDeal.Unlock()
+	if mock.SaveDealFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SaveDealFunc(ctx, deal)
+}
+
+// SaveDealCalls gets all the calls that were made to SaveDeal.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.SaveDealCalls())
+func (mock *CampaignRepositoryMock) SaveDealCalls() []struct {
+	Ctx  context.Context
+	Deal model.Deal
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Deal model.Deal
+	}
+	mock.lockSaveDeal.RLock()
+	calls = mock.calls.SaveDeal
+	mock.lockSaveDeal.RUnlock()
+	return calls
+}
+
+// SaveFloorRule calls SaveFloorRuleFunc.
+func (mock *CampaignRepositoryMock) SaveFloorRule(ctx context.Context, rule model.FloorRule) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Rule model.FloorRule
+	}{
+		Ctx:  ctx,
+		Rule: rule,
+	}
+	mock.lockSaveFloorRule.Lock()
+	mock.calls.SaveFloorRule = append(mock.calls.SaveFloorRule, callInfo)
+	mock.lockSaveFloorRule.Unlock()
+	if mock.SaveFloorRuleFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SaveFloorRuleFunc(ctx, rule)
+}
+
+// SaveFloorRuleCalls gets all the calls that were made to SaveFloorRule.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.SaveFloorRuleCalls())
+func (mock *CampaignRepositoryMock) SaveFloorRuleCalls() []struct {
+	Ctx  context.Context
+	Rule model.FloorRule
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Rule model.FloorRule
+	}
+	mock.lockSaveFloorRule.RLock()
+	calls = mock.calls.SaveFloorRule
+	mock.lockSaveFloorRule.RUnlock()
+	return calls
+}
+
+// TrackEvent calls TrackEventFunc.
+func (mock *CampaignRepositoryMock) TrackEvent(ctx context.Context, tracking model.Tracking, event model.EventType) (model.Campaign, error) {
+	callInfo := struct {
+		Ctx      context.Context
+		Tracking model.Tracking
+		Event    model.EventType
+	}{
+		Ctx:      ctx,
+		Tracking: tracking,
+		Event:    event,
+	}
+	mock.lockTrackEvent.Lock()
+	mock.calls.TrackEvent = append(mock.calls.TrackEvent, callInfo)
+	mock.lockTrackEvent.Unlock()
+	if mock.TrackEventFunc == nil {
+		var (
+			campaignOut model.Campaign
+			errOut      error
+		)
+		return campaignOut, errOut
+	}
+	return mock.TrackEventFunc(ctx, tracking, event)
+}
+
+// TrackEventCalls gets all the calls that were made to TrackEvent.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.TrackEventCalls())
+func (mock *CampaignRepositoryMock) TrackEventCalls() []struct {
+	Ctx      context.Context
+	Tracking model.Tracking
+	Event    model.EventType
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Tracking model.Tracking
+		Event    model.EventType
+	}
+	mock.lockTrackEvent.RLock()
+	calls = mock.calls.TrackEvent
+	mock.lockTrackEvent.RUnlock()
+	return calls
+}
+
+// UpdateCreativeReview calls UpdateCreativeReviewFunc.
+func (mock *CampaignRepositoryMock) UpdateCreativeReview(ctx context.Context, campaignID string, creativeID string, review model.Review) error {
+	callInfo := struct {
+		Ctx        context.Context
+		CampaignID string
+		CreativeID string
+		Review     model.Review
+	}{
+		Ctx:        ctx,
+		CampaignID: campaignID,
+		CreativeID: creativeID,
+		Review:     review,
+	}
+	mock.lockUpdateCreativeReview.Lock()
+	mock.calls.UpdateCreativeReview = append(mock.calls.UpdateCreativeReview, callInfo)
+	mock.lockUpdateCreativeReview.Unlock()
+	if mock.UpdateCreativeReviewFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.UpdateCreativeReviewFunc(ctx, campaignID, creativeID, review)
+}
+
+// UpdateCreativeReviewCalls gets all the calls that were made to UpdateCreativeReview.
+// Check the length with:
+//
+//	len(mockedCampaignRepository.UpdateCreativeReviewCalls())
+func (mock *CampaignRepositoryMock) UpdateCreativeReviewCalls() []struct {
+	Ctx        context.Context
+	CampaignID string
+	CreativeID string
+	Review     model.Review
+} {
+	var calls []struct {
+		Ctx        context.Context
+		CampaignID string
+		CreativeID string
+		Review     model.Review
+	}
+	mock.lockUpdateCreativeReview.RLock()
+	calls = mock.calls.UpdateCreativeReview
+	mock.lockUpdateCreativeReview.RUnlock()
+	return calls
+}
+
+// WinDelivery calls WinDeliveryFunc.
+func (mock *CampaignRepositoryMock) WinDelivery(ctx context.Context, reservationID string, price decimal.Decimal) error {
+	callInfo := struct {
+		Ctx           context.Context
+		ReservationID string
+		Price         decimal.Decimal
+	}{
+		Ctx:           ctx,
+		ReservationID: reservationID,
+		Price:         price,
+	}
+	mock.lockWinDelivery.Lock()
+	mock.calls.WinDelivery = append(mock.calls.WinDelivery, callInfo)
+	mock.lockWinDelivery.Unlock()
+	if mock.WinDeliveryFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
 	}
-	return mock.MatchCampaignFunc(ctx, country, device, os)
+	return mock.WinDeliveryFunc(ctx, reservationID, price)
 }
 
-// MatchCampaignCalls gets all the calls that were made to MatchCampaign.
+// WinDeliveryCalls gets all the calls that were made to WinDelivery.
 // Check the length with:
 //
-//	len(mockedCampaignRepository.MatchCampaignCalls())
-func (mock *CampaignRepositoryMock) MatchCampaignCalls() []struct {
-	Ctx     context.Context
-	Country model.Country
-	Device  model.Device
-	Os      model.OS
+//	len(mockedCampaignRepository.WinDeliveryCalls())
+func (mock *CampaignRepositoryMock) WinDeliveryCalls() []struct {
+	Ctx           context.Context
+	ReservationID string
+	Price         decimal.Decimal
 } {
 	var calls []struct {
-		Ctx     context.Context
-		Country model.Country
-		Device  model.Device
-		Os      model.OS
+		Ctx           context.Context
+		ReservationID string
+		Price         decimal.Decimal
 	}
-	mock.lockMatchCampaign.RLock()
-	calls = mock.calls.MatchCampaign
-	mock.lockMatchCampaign.RUnlock()
+	mock.lockWinDelivery.RLock()
+	calls = mock.calls.WinDelivery
+	mock.lockWinDelivery.RUnlock()
 	return calls
 }
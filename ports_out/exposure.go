@@ -0,0 +1,17 @@
+package ports_out
+
+import (
+	"ad-campaign-delivery/model"
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq -out exposure_mock.go -stub . ExposureRepository
+type ExposureRepository interface {
+	FindExposures(ctx context.Context, userID string) (model.Exposures, error)
+	RecordExposures(ctx context.Context, userID string, campaignIDs []string, at time.Time) error
+	FindPageView(ctx context.Context, pageViewID string) (model.PageView, error)
+	RecordPageView(ctx context.Context, pageViewID string, pageView model.PageView, at time.Time) error
+	ForgetDeliveries(ctx context.Context, deliveries []model.Delivery) error
+	DeleteExpiredExposures()
+}